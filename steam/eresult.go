@@ -0,0 +1,317 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+// Package steam holds types shared by the Steamworks and WebAPI packages.
+package steam
+
+import (
+	"fmt"
+)
+
+// EResult is the result code Steam reports for calls, both from the
+// Steamworks API and in WebAPI responses (as a success, result or eresult
+// value, or the X-eresult header).
+//
+// An EResult is also an error, so a failure can be matched directly:
+//
+//	errors.Is(err, steam.EResultAccessDenied)
+type EResult int
+
+// The result codes (4 is unused).
+const (
+	EResultNone                                    EResult = 0
+	EResultOK                                      EResult = 1
+	EResultFail                                    EResult = 2
+	EResultNoConnection                            EResult = 3
+	EResultInvalidPassword                         EResult = 5
+	EResultLoggedInElsewhere                       EResult = 6
+	EResultInvalidProtocolVer                      EResult = 7
+	EResultInvalidParam                            EResult = 8
+	EResultFileNotFound                            EResult = 9
+	EResultBusy                                    EResult = 10
+	EResultInvalidState                            EResult = 11
+	EResultInvalidName                             EResult = 12
+	EResultInvalidEmail                            EResult = 13
+	EResultDuplicateName                           EResult = 14
+	EResultAccessDenied                            EResult = 15
+	EResultTimeout                                 EResult = 16
+	EResultBanned                                  EResult = 17
+	EResultAccountNotFound                         EResult = 18
+	EResultInvalidSteamID                          EResult = 19
+	EResultServiceUnavailable                      EResult = 20
+	EResultNotLoggedOn                             EResult = 21
+	EResultPending                                 EResult = 22
+	EResultEncryptionFailure                       EResult = 23
+	EResultInsufficientPrivilege                   EResult = 24
+	EResultLimitExceeded                           EResult = 25
+	EResultRevoked                                 EResult = 26
+	EResultExpired                                 EResult = 27
+	EResultAlreadyRedeemed                         EResult = 28
+	EResultDuplicateRequest                        EResult = 29
+	EResultAlreadyOwned                            EResult = 30
+	EResultIPNotFound                              EResult = 31
+	EResultPersistFailed                           EResult = 32
+	EResultLockingFailed                           EResult = 33
+	EResultLogonSessionReplaced                    EResult = 34
+	EResultConnectFailed                           EResult = 35
+	EResultHandshakeFailed                         EResult = 36
+	EResultIOFailure                               EResult = 37
+	EResultRemoteDisconnect                        EResult = 38
+	EResultShoppingCartNotFound                    EResult = 39
+	EResultBlocked                                 EResult = 40
+	EResultIgnored                                 EResult = 41
+	EResultNoMatch                                 EResult = 42
+	EResultAccountDisabled                         EResult = 43
+	EResultServiceReadOnly                         EResult = 44
+	EResultAccountNotFeatured                      EResult = 45
+	EResultAdministratorOK                         EResult = 46
+	EResultContentVersion                          EResult = 47
+	EResultTryAnotherCM                            EResult = 48
+	EResultPasswordRequiredToKickSession           EResult = 49
+	EResultAlreadyLoggedInElsewhere                EResult = 50
+	EResultSuspended                               EResult = 51
+	EResultCancelled                               EResult = 52
+	EResultDataCorruption                          EResult = 53
+	EResultDiskFull                                EResult = 54
+	EResultRemoteCallFailed                        EResult = 55
+	EResultPasswordUnset                           EResult = 56
+	EResultExternalAccountUnlinked                 EResult = 57
+	EResultPSNTicketInvalid                        EResult = 58
+	EResultExternalAccountAlreadyLinked            EResult = 59
+	EResultRemoteFileConflict                      EResult = 60
+	EResultIllegalPassword                         EResult = 61
+	EResultSameAsPreviousValue                     EResult = 62
+	EResultAccountLogonDenied                      EResult = 63
+	EResultCannotUseOldPassword                    EResult = 64
+	EResultInvalidLoginAuthCode                    EResult = 65
+	EResultAccountLogonDeniedNoMail                EResult = 66
+	EResultHardwareNotCapableOfIPT                 EResult = 67
+	EResultIPTInitError                            EResult = 68
+	EResultParentalControlRestricted               EResult = 69
+	EResultFacebookQueryError                      EResult = 70
+	EResultExpiredLoginAuthCode                    EResult = 71
+	EResultIPLoginRestrictionFailed                EResult = 72
+	EResultAccountLockedDown                       EResult = 73
+	EResultAccountLogonDeniedVerifiedEmailRequired EResult = 74
+	EResultNoMatchingURL                           EResult = 75
+	EResultBadResponse                             EResult = 76
+	EResultRequirePasswordReEntry                  EResult = 77
+	EResultValueOutOfRange                         EResult = 78
+	EResultUnexpectedError                         EResult = 79
+	EResultDisabled                                EResult = 80
+	EResultInvalidCEGSubmission                    EResult = 81
+	EResultRestrictedDevice                        EResult = 82
+	EResultRegionLocked                            EResult = 83
+	EResultRateLimitExceeded                       EResult = 84
+	EResultAccountLoginDeniedNeedTwoFactor         EResult = 85
+	EResultItemDeleted                             EResult = 86
+	EResultAccountLoginDeniedThrottle              EResult = 87
+	EResultTwoFactorCodeMismatch                   EResult = 88
+	EResultTwoFactorActivationCodeMismatch         EResult = 89
+	EResultAccountAssociatedToMultiplePartners     EResult = 90
+	EResultNotModified                             EResult = 91
+	EResultNoMobileDevice                          EResult = 92
+	EResultTimeNotSynced                           EResult = 93
+	EResultSmsCodeFailed                           EResult = 94
+	EResultAccountLimitExceeded                    EResult = 95
+	EResultAccountActivityLimitExceeded            EResult = 96
+	EResultPhoneActivityLimitExceeded              EResult = 97
+	EResultRefundToWallet                          EResult = 98
+	EResultEmailSendFailure                        EResult = 99
+	EResultNotSettled                              EResult = 100
+	EResultNeedCaptcha                             EResult = 101
+	EResultGSLTDenied                              EResult = 102
+	EResultGSOwnerDenied                           EResult = 103
+	EResultInvalidItemType                         EResult = 104
+	EResultIPBanned                                EResult = 105
+	EResultGSLTExpired                             EResult = 106
+	EResultInsufficientFunds                       EResult = 107
+	EResultTooManyPending                          EResult = 108
+	EResultNoSiteLicensesFound                     EResult = 109
+	EResultWGNetworkSendExceeded                   EResult = 110
+	EResultAccountNotFriends                       EResult = 111
+	EResultLimitedUserAccount                      EResult = 112
+	EResultCantRemoveItem                          EResult = 113
+	EResultAccountDeleted                          EResult = 114
+	EResultExistingUserCancelledLicense            EResult = 115
+	EResultCommunityCooldown                       EResult = 116
+	EResultNoLauncherSpecified                     EResult = 117
+	EResultMustAgreeToSSA                          EResult = 118
+	EResultLauncherMigrated                        EResult = 119
+	EResultSteamRealmMismatch                      EResult = 120
+	EResultInvalidSignature                        EResult = 121
+	EResultParseFailure                            EResult = 122
+	EResultNoVerifiedPhone                         EResult = 123
+	EResultInsufficientBattery                     EResult = 124
+	EResultChargerRequired                         EResult = 125
+	EResultCachedCredentialInvalid                 EResult = 126
+	EResultPhoneNumberIsVOIP                       EResult = 127
+	EResultNotSupported                            EResult = 128
+)
+
+var eresults = map[EResult]struct {
+	name        string
+	description string
+}{
+	EResultNone:                                    {"None", "no result"},
+	EResultOK:                                      {"OK", "success"},
+	EResultFail:                                    {"Fail", "generic failure"},
+	EResultNoConnection:                            {"NoConnection", "no or failed network connection"},
+	EResultInvalidPassword:                         {"InvalidPassword", "password or ticket is invalid"},
+	EResultLoggedInElsewhere:                       {"LoggedInElsewhere", "same user logged in elsewhere"},
+	EResultInvalidProtocolVer:                      {"InvalidProtocolVer", "protocol version is incorrect"},
+	EResultInvalidParam:                            {"InvalidParam", "a parameter is incorrect"},
+	EResultFileNotFound:                            {"FileNotFound", "file was not found"},
+	EResultBusy:                                    {"Busy", "called method is busy; no action was taken"},
+	EResultInvalidState:                            {"InvalidState", "called object was in an invalid state"},
+	EResultInvalidName:                             {"InvalidName", "the name was invalid"},
+	EResultInvalidEmail:                            {"InvalidEmail", "the email was invalid"},
+	EResultDuplicateName:                           {"DuplicateName", "the name is not unique"},
+	EResultAccessDenied:                            {"AccessDenied", "access is denied"},
+	EResultTimeout:                                 {"Timeout", "operation timed out"},
+	EResultBanned:                                  {"Banned", "the user is VAC2 banned"},
+	EResultAccountNotFound:                         {"AccountNotFound", "account not found"},
+	EResultInvalidSteamID:                          {"InvalidSteamID", "the SteamID was invalid"},
+	EResultServiceUnavailable:                      {"ServiceUnavailable", "the requested service is currently unavailable"},
+	EResultNotLoggedOn:                             {"NotLoggedOn", "the user is not logged on"},
+	EResultPending:                                 {"Pending", "request is pending, it may be in process or waiting on a third party"},
+	EResultEncryptionFailure:                       {"EncryptionFailure", "encryption or decryption failed"},
+	EResultInsufficientPrivilege:                   {"InsufficientPrivilege", "insufficient privilege"},
+	EResultLimitExceeded:                           {"LimitExceeded", "too much of a good thing"},
+	EResultRevoked:                                 {"Revoked", "access has been revoked (used for revoked guest passes)"},
+	EResultExpired:                                 {"Expired", "license or guest pass the user is trying to access is expired"},
+	EResultAlreadyRedeemed:                         {"AlreadyRedeemed", "guest pass has already been redeemed by this account"},
+	EResultDuplicateRequest:                        {"DuplicateRequest", "the request is a duplicate and the action has already occurred"},
+	EResultAlreadyOwned:                            {"AlreadyOwned", "all the games in the guest pass redemption request are already owned"},
+	EResultIPNotFound:                              {"IPNotFound", "IP address not found"},
+	EResultPersistFailed:                           {"PersistFailed", "failed to write change to the data store"},
+	EResultLockingFailed:                           {"LockingFailed", "failed to acquire access lock for this operation"},
+	EResultLogonSessionReplaced:                    {"LogonSessionReplaced", "the logon session has been replaced"},
+	EResultConnectFailed:                           {"ConnectFailed", "failed to connect"},
+	EResultHandshakeFailed:                         {"HandshakeFailed", "the authentication handshake has failed"},
+	EResultIOFailure:                               {"IOFailure", "there has been a generic IO failure"},
+	EResultRemoteDisconnect:                        {"RemoteDisconnect", "the remote server has disconnected"},
+	EResultShoppingCartNotFound:                    {"ShoppingCartNotFound", "failed to find the shopping cart requested"},
+	EResultBlocked:                                 {"Blocked", "a user blocked the action"},
+	EResultIgnored:                                 {"Ignored", "the target is ignoring the sender"},
+	EResultNoMatch:                                 {"NoMatch", "nothing matching the request found"},
+	EResultAccountDisabled:                         {"AccountDisabled", "the account is disabled"},
+	EResultServiceReadOnly:                         {"ServiceReadOnly", "this service is not accepting content changes right now"},
+	EResultAccountNotFeatured:                      {"AccountNotFeatured", "account doesn't have value, so this feature isn't available"},
+	EResultAdministratorOK:                         {"AdministratorOK", "allowed to take this action, but only because the requester is an admin"},
+	EResultContentVersion:                          {"ContentVersion", "a version mismatch in content transmitted within the Steam protocol"},
+	EResultTryAnotherCM:                            {"TryAnotherCM", "the current CM can't service the user making a request"},
+	EResultPasswordRequiredToKickSession:           {"PasswordRequiredToKickSession", "you are already logged in elsewhere, this cached credential login has failed"},
+	EResultAlreadyLoggedInElsewhere:                {"AlreadyLoggedInElsewhere", "the user is logged in elsewhere"},
+	EResultSuspended:                               {"Suspended", "long running operation has suspended or paused"},
+	EResultCancelled:                               {"Cancelled", "operation has been cancelled, typically by the user"},
+	EResultDataCorruption:                          {"DataCorruption", "operation cancelled because data is ill formed or unrecoverable"},
+	EResultDiskFull:                                {"DiskFull", "operation cancelled, not enough disk space"},
+	EResultRemoteCallFailed:                        {"RemoteCallFailed", "the remote or IPC call has failed"},
+	EResultPasswordUnset:                           {"PasswordUnset", "password could not be verified as it's unset server side"},
+	EResultExternalAccountUnlinked:                 {"ExternalAccountUnlinked", "external account (PSN, Facebook...) is not linked to a Steam account"},
+	EResultPSNTicketInvalid:                        {"PSNTicketInvalid", "PSN ticket was invalid"},
+	EResultExternalAccountAlreadyLinked:            {"ExternalAccountAlreadyLinked", "external account (PSN, Facebook...) is already linked to some other account"},
+	EResultRemoteFileConflict:                      {"RemoteFileConflict", "the sync cannot resume due to a conflict between the local and remote files"},
+	EResultIllegalPassword:                         {"IllegalPassword", "the requested new password is not allowed"},
+	EResultSameAsPreviousValue:                     {"SameAsPreviousValue", "new value is the same as the old one"},
+	EResultAccountLogonDenied:                      {"AccountLogonDenied", "account login denied due to 2nd factor authentication failure"},
+	EResultCannotUseOldPassword:                    {"CannotUseOldPassword", "the requested new password is not legal"},
+	EResultInvalidLoginAuthCode:                    {"InvalidLoginAuthCode", "account login denied due to auth code invalid"},
+	EResultAccountLogonDeniedNoMail:                {"AccountLogonDeniedNoMail", "account login denied due to 2nd factor auth failure, and no mail has been sent"},
+	EResultHardwareNotCapableOfIPT:                 {"HardwareNotCapableOfIPT", "the user's hardware does not support Intel's Identity Protection Technology"},
+	EResultIPTInitError:                            {"IPTInitError", "Intel's Identity Protection Technology has failed to initialize"},
+	EResultParentalControlRestricted:               {"ParentalControlRestricted", "operation failed due to parental control restrictions for the current user"},
+	EResultFacebookQueryError:                      {"FacebookQueryError", "Facebook query returned an error"},
+	EResultExpiredLoginAuthCode:                    {"ExpiredLoginAuthCode", "account login denied due to an expired auth code"},
+	EResultIPLoginRestrictionFailed:                {"IPLoginRestrictionFailed", "the login failed due to an IP restriction"},
+	EResultAccountLockedDown:                       {"AccountLockedDown", "the current user's account is currently locked for use"},
+	EResultAccountLogonDeniedVerifiedEmailRequired: {"AccountLogonDeniedVerifiedEmailRequired", "the logon failed because the account's email is not verified"},
+	EResultNoMatchingURL:                           {"NoMatchingURL", "there is no URL matching the provided values"},
+	EResultBadResponse:                             {"BadResponse", "bad response due to a parse failure, missing field, etc."},
+	EResultRequirePasswordReEntry:                  {"RequirePasswordReEntry", "the user cannot complete the action until they re-enter their password"},
+	EResultValueOutOfRange:                         {"ValueOutOfRange", "the value entered is outside the acceptable range"},
+	EResultUnexpectedError:                         {"UnexpectedError", "something happened that we didn't expect to ever happen"},
+	EResultDisabled:                                {"Disabled", "the requested service has been configured to be unavailable"},
+	EResultInvalidCEGSubmission:                    {"InvalidCEGSubmission", "the files submitted to the CEG server are not valid"},
+	EResultRestrictedDevice:                        {"RestrictedDevice", "the device being used is not allowed to perform this action"},
+	EResultRegionLocked:                            {"RegionLocked", "the action could not be complete because it is region restricted"},
+	EResultRateLimitExceeded:                       {"RateLimitExceeded", "temporary rate limit exceeded, try again later"},
+	EResultAccountLoginDeniedNeedTwoFactor:         {"AccountLoginDeniedNeedTwoFactor", "need two-factor code to login"},
+	EResultItemDeleted:                             {"ItemDeleted", "the thing we're trying to access has been deleted"},
+	EResultAccountLoginDeniedThrottle:              {"AccountLoginDeniedThrottle", "login attempt failed, try to throttle response to possible attacker"},
+	EResultTwoFactorCodeMismatch:                   {"TwoFactorCodeMismatch", "two-factor code mismatch"},
+	EResultTwoFactorActivationCodeMismatch:         {"TwoFactorActivationCodeMismatch", "activation code for two-factor didn't match"},
+	EResultAccountAssociatedToMultiplePartners:     {"AccountAssociatedToMultiplePartners", "the account has been associated with multiple partners"},
+	EResultNotModified:                             {"NotModified", "data not modified"},
+	EResultNoMobileDevice:                          {"NoMobileDevice", "the account does not have a mobile device associated with it"},
+	EResultTimeNotSynced:                           {"TimeNotSynced", "the time presented is out of range or tolerance"},
+	EResultSmsCodeFailed:                           {"SmsCodeFailed", "SMS code failure"},
+	EResultAccountLimitExceeded:                    {"AccountLimitExceeded", "too many accounts access this resource"},
+	EResultAccountActivityLimitExceeded:            {"AccountActivityLimitExceeded", "too many changes to this account"},
+	EResultPhoneActivityLimitExceeded:              {"PhoneActivityLimitExceeded", "too many changes to this phone"},
+	EResultRefundToWallet:                          {"RefundToWallet", "cannot refund to payment method, must use wallet"},
+	EResultEmailSendFailure:                        {"EmailSendFailure", "cannot send an email"},
+	EResultNotSettled:                              {"NotSettled", "can't perform operation until payment has settled"},
+	EResultNeedCaptcha:                             {"NeedCaptcha", "the user needs to provide a valid captcha"},
+	EResultGSLTDenied:                              {"GSLTDenied", "a game server login token owned by this token's owner has been banned"},
+	EResultGSOwnerDenied:                           {"GSOwnerDenied", "game server owner is denied for some other reason"},
+	EResultInvalidItemType:                         {"InvalidItemType", "the type of thing we were requested to act on is invalid"},
+	EResultIPBanned:                                {"IPBanned", "the IP address has been banned from taking this action"},
+	EResultGSLTExpired:                             {"GSLTExpired", "this game server login token has expired from disuse"},
+	EResultInsufficientFunds:                       {"InsufficientFunds", "user doesn't have enough wallet funds to complete the action"},
+	EResultTooManyPending:                          {"TooManyPending", "there are too many of this thing pending already"},
+	EResultNoSiteLicensesFound:                     {"NoSiteLicensesFound", "no site licenses found"},
+	EResultWGNetworkSendExceeded:                   {"WGNetworkSendExceeded", "the WG couldn't send a response because we exceeded max network send size"},
+	EResultAccountNotFriends:                       {"AccountNotFriends", "the user is not mutually friends"},
+	EResultLimitedUserAccount:                      {"LimitedUserAccount", "the user is limited"},
+	EResultCantRemoveItem:                          {"CantRemoveItem", "item can't be removed"},
+	EResultAccountDeleted:                          {"AccountDeleted", "account has been deleted"},
+	EResultExistingUserCancelledLicense:            {"ExistingUserCancelledLicense", "a license for this already exists, but cancelled"},
+	EResultCommunityCooldown:                       {"CommunityCooldown", "access is denied because of a community cooldown (probably from support profile data resets)"},
+	EResultNoLauncherSpecified:                     {"NoLauncherSpecified", "no launcher was specified, but a launcher was needed to choose correct realm for operation"},
+	EResultMustAgreeToSSA:                          {"MustAgreeToSSA", "user must agree to China SSA or global SSA before login"},
+	EResultLauncherMigrated:                        {"LauncherMigrated", "the specified launcher type is no longer supported"},
+	EResultSteamRealmMismatch:                      {"SteamRealmMismatch", "the user's realm does not match the realm of the requested resource"},
+	EResultInvalidSignature:                        {"InvalidSignature", "signature check did not match"},
+	EResultParseFailure:                            {"ParseFailure", "failed to parse input"},
+	EResultNoVerifiedPhone:                         {"NoVerifiedPhone", "account does not have a verified phone number"},
+	EResultInsufficientBattery:                     {"InsufficientBattery", "the device's battery is too low to complete the action"},
+	EResultChargerRequired:                         {"ChargerRequired", "the operation requires a charger to be plugged in"},
+	EResultCachedCredentialInvalid:                 {"CachedCredentialInvalid", "cached credential was invalid, the user must reauthenticate"},
+	EResultPhoneNumberIsVOIP:                       {"PhoneNumberIsVOIP", "the phone number provided is a Voice Over IP number"},
+	EResultNotSupported:                            {"NotSupported", "the data being accessed is not supported by this API"},
+}
+
+// String returns the name of the result (e.g. "AccessDenied").
+func (r EResult) String() string {
+	if info, ok := eresults[r]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("EResult(%d)", int(r))
+}
+
+// Description returns a short description of the result.
+func (r EResult) Description() string {
+	if info, ok := eresults[r]; ok {
+		return info.description
+	}
+	return "unknown result"
+}
+
+// Error implements error.
+func (r EResult) Error() string {
+	return fmt.Sprintf("%s (%d): %s", r.String(), int(r), r.Description())
+}
+
+// OK reports whether the result is a success.
+func (r EResult) OK() bool {
+	return r == EResultOK
+}
+
+// Known reports whether the result is in the table.
+func (r EResult) Known() bool {
+	_, ok := eresults[r]
+	return ok
+}
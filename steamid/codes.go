@@ -0,0 +1,144 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package steamid
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Invite codes are the account ID in hex, with the digits swapped for these.
+const (
+	inviteHex    = "0123456789abcdef"
+	inviteDigits = "bcdfghjkmnpqrtvw"
+)
+
+// InviteCode returns the user's quick invite code, as used in
+// https://s.team/p/<code> links (e.g. 22202 is "hj-qp"); codes of four or
+// more letters are split in the middle.
+func (id SteamID) InviteCode() string {
+	hex := strconv.FormatUint(uint64(id.AccountID()), 16)
+	code := make([]byte, len(hex))
+	for i := 0; i < len(hex); i++ {
+		code[i] = inviteDigits[strings.IndexByte(inviteHex, hex[i])]
+	}
+	if len(code) < 4 {
+		return string(code)
+	}
+	half := len(code) / 2
+	return string(code[:half]) + "-" + string(code[half:])
+}
+
+// InviteURL returns the user's quick invite link (https://s.team/p/<code>).
+func (id SteamID) InviteURL() string {
+	return "https://s.team/p/" + id.InviteCode()
+}
+
+// ParseInviteCode parses a quick invite code ("hj-qp"), or a link holding
+// one ("https://s.team/p/hj-qp/TOKEN"), into the user's SteamID.
+func ParseInviteCode(code string) (SteamID, error) {
+	s := strings.ToLower(strings.TrimSpace(code))
+	if i := strings.Index(s, "s.team/p/"); i >= 0 {
+		s = s[i+len("s.team/p/"):]
+		if j := strings.IndexAny(s, "/?#"); j >= 0 {
+			s = s[:j]
+		}
+	}
+	s = strings.Replace(s, "-", "", -1)
+	if s == "" || len(s) > 8 {
+		return 0, &ParseError{Input: code, Reason: "not an invite code"}
+	}
+
+	var accountID uint32
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(inviteDigits, s[i])
+		if digit < 0 {
+			return 0, &ParseError{Input: code, Reason: "not an invite code"}
+		}
+		accountID = accountID<<4 | uint32(digit)
+	}
+	return checked(code, NewIndividual(accountID))
+}
+
+// Friend codes are written in base 32 with these digits.
+const friendCodeDigits = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Every friend code starts with this, so it is left off.
+const friendCodePrefix = "AAAA-"
+
+// friendCodeHash returns the checksum bits mixed into a friend code.
+func friendCodeHash(accountID uint32) uint32 {
+	var data [8]byte
+	binary.LittleEndian.PutUint64(data[:], uint64(accountID)|0x4353474F00000000) // "CSGO"
+	sum := md5.Sum(data[:])
+	return binary.LittleEndian.Uint32(sum[:4])
+}
+
+// FriendCode returns the user's CS:GO friend code (e.g. 22202 is
+// "SUCVS-FADA").
+func (id SteamID) FriendCode() string {
+	accountID := id.AccountID()
+	hash := friendCodeHash(accountID)
+
+	// Each nibble of the account ID is followed by a bit of the hash
+	var r uint64
+	for i := uint(0); i < 8; i++ {
+		nibble := uint64(accountID>>(i*4)) & 0xF
+		a := r<<4 | nibble
+		r = (r>>28)<<32 | uint64(uint32(a))
+		r = (r>>31)<<32 | uint64(uint32(a<<1|uint64(hash>>i)&1))
+	}
+	r = bits.ReverseBytes64(r)
+
+	var code strings.Builder
+	for i := 0; i < 13; i++ {
+		if i == 4 || i == 9 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(friendCodeDigits[r&0x1F])
+		r >>= 5
+	}
+	return strings.TrimPrefix(code.String(), friendCodePrefix)
+}
+
+// ParseFriendCode parses a CS:GO friend code ("SUCVS-FADA", with or
+// without the leading "AAAA-") into the user's SteamID. Codes whose
+// checksum does not match are rejected.
+func ParseFriendCode(code string) (SteamID, error) {
+	s := strings.ToUpper(strings.TrimSpace(code))
+	if !strings.HasPrefix(s, friendCodePrefix) {
+		s = friendCodePrefix + s
+	}
+	s = strings.Replace(s, "-", "", -1)
+	if len(s) != 13 {
+		return 0, &ParseError{Input: code, Reason: "not a friend code"}
+	}
+
+	var r uint64
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(friendCodeDigits, s[i])
+		if digit < 0 {
+			return 0, &ParseError{Input: code, Reason: "not a friend code"}
+		}
+		r |= uint64(digit) << (5 * uint(i))
+	}
+	r = bits.ReverseBytes64(r)
+
+	var accountID uint32
+	for i := 0; i < 8; i++ {
+		r >>= 1
+		accountID = accountID<<4 | uint32(r&0xF)
+		r >>= 4
+	}
+
+	id := NewIndividual(accountID)
+	if strings.Replace(friendCodePrefix+id.FriendCode(), "-", "", -1) != s {
+		return 0, &ParseError{Input: code, Reason: "friend code checksum does not match"}
+	}
+	return checked(code, id)
+}
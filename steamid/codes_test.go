@@ -0,0 +1,136 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package steamid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Account IDs, their SteamID64s, invite codes and friend codes. 22202
+// (Gabe Newell, 76561197960287930) is the pair published with the friend
+// code format; the rest pin the encodings down at the edges.
+var codeTests = []struct {
+	accountID  uint32
+	steamID64  SteamID
+	invite     string
+	friendCode string
+}{
+	{0, 76561197960265728, "b", "AEJJS-ABCA"},
+	{1, 76561197960265729, "c", "AJJJS-ABAA"},
+	{2, 76561197960265730, "d", "AWAAA-AADA"},
+	{15, 76561197960265743, "w", "A2DJA-AAAC"},
+	{16, 76561197960265744, "cb", "AAJ2S-ABBA"},
+	{0x123, 76561197960266019, "cdf", "S6SAS-ABBC"},
+	{0x1234, 76561197960270388, "cd-fg", "SABTA-BADA"},
+	{22202, 76561197960287930, "hj-qp", "SUCVS-FADA"},
+	{46143802, 76561198006409530, "drb-cnfp", "SWUWA-B2BL"},
+	{0x12345678, 76561198265685624, "cdfg-hjkm", "SBCVE-FGEJ"},
+	{0x7FFFFFFF, 76561200107749375, "kwww-wwww", "S5999-998Q"},
+	{0xFFFFFFFF, 76561202255233023, "wwww-wwww", "S9ZZR-999P"},
+}
+
+func TestCodes(t *testing.T) {
+	for _, test := range codeTests {
+		id := NewIndividual(test.accountID)
+		if id != test.steamID64 {
+			t.Errorf("%d: NewIndividual = %d, want %d", test.accountID, uint64(id), uint64(test.steamID64))
+		}
+		if got := id.InviteCode(); got != test.invite {
+			t.Errorf("%d: InviteCode() = %s, want %s", test.accountID, got, test.invite)
+		}
+		if got := id.InviteURL(); got != "https://s.team/p/"+test.invite {
+			t.Errorf("%d: InviteURL() = %s", test.accountID, got)
+		}
+		if got := id.FriendCode(); got != test.friendCode {
+			t.Errorf("%d: FriendCode() = %s, want %s", test.accountID, got, test.friendCode)
+		}
+	}
+}
+
+func TestParseCodesRoundTrip(t *testing.T) {
+	for _, test := range codeTests {
+		if test.accountID == 0 {
+			// Account 0 is not a valid SteamID
+			continue
+		}
+
+		invites := []string{
+			test.invite,
+			strings.Replace(test.invite, "-", "", -1),
+			strings.ToUpper(test.invite),
+			"https://s.team/p/" + test.invite,
+			"https://s.team/p/" + test.invite + "/ABCDEFGH",
+			"s.team/p/" + strings.ToUpper(test.invite) + "?x=1",
+		}
+		for _, input := range invites {
+			got, err := ParseInviteCode(input)
+			if err != nil || got != test.steamID64 {
+				t.Errorf("ParseInviteCode(%q) = %d, %v, want %d", input, uint64(got), err, uint64(test.steamID64))
+			}
+		}
+
+		friendCodes := []string{
+			test.friendCode,
+			"AAAA-" + test.friendCode,
+			strings.ToLower(test.friendCode),
+			strings.ToLower("AAAA-" + test.friendCode),
+			strings.Replace(test.friendCode, "-", "", -1),
+			" " + test.friendCode + " ",
+		}
+		for _, input := range friendCodes {
+			got, err := ParseFriendCode(input)
+			if err != nil || got != test.steamID64 {
+				t.Errorf("ParseFriendCode(%q) = %d, %v, want %d", input, uint64(got), err, uint64(test.steamID64))
+			}
+		}
+	}
+}
+
+func TestParseInviteCodeInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"-",
+		"b",           // account 0
+		"bbbb-bbbb",   // account 0
+		"hj-qa",       // a is not an invite digit
+		"hj-q1",       // nor are digits
+		"hj-qe",       // nor are vowels
+		"hj qp",       // nor is a space
+		"bbbbb-bbbbb", // over 8 characters
+		"cbbbbbbbb",   // over 8 characters
+		"wwww-wwww-w", // over 8 characters
+		"https://s.team/p/",
+		"https://s.team/p/bcdfg-hjkmn/ABCDEFGH",
+	} {
+		if got, err := ParseInviteCode(input); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q: got %d, %v, want ErrInvalid", input, uint64(got), err)
+		}
+	}
+}
+
+func TestParseFriendCodeInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"AEJJS-ABCA",       // account 0
+		"SUCVS-FADB",       // checksum mismatch
+		"SUCVS-FAEA",       // checksum mismatch
+		"TUCVS-FADA",       // checksum mismatch
+		"S9ZZR-999Q",       // checksum mismatch
+		"AJJJS-ABAB",       // checksum mismatch
+		"SUCV1-FADA",       // 1 is not a friend code digit
+		"SUCVO-FADA",       // nor is O
+		"SUCVI-FADA",       // nor is I
+		"SUCVS-FADAA",      // too long
+		"AAAA-SUCVS-FADAA", // too long
+		"SUCVS-FAD",        // too short
+		"BBBB-SUCVS-FADA",  // wrong prefix
+	} {
+		if got, err := ParseFriendCode(input); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q: got %d, %v, want ErrInvalid", input, uint64(got), err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package steamid
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// The SteamID3 letters of each account type.
+var typeLetters = map[AccountType]byte{
+	TypeInvalid:        'I',
+	TypeIndividual:     'U',
+	TypeMultiseat:      'M',
+	TypeGameServer:     'G',
+	TypeAnonGameServer: 'A',
+	TypePending:        'P',
+	TypeContentServer:  'C',
+	TypeClan:           'g',
+	TypeChat:           'T',
+	TypeAnonUser:       'a',
+}
+
+// String returns the SteamID64 (e.g. 76561197960287930).
+func (id SteamID) String() string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// Steam2 returns the SteamID2 (e.g. STEAM_0:0:11101).
+//
+// The public universe is written as 0, as older games (and most tools)
+// do; newer games write it as 1, which Parse also accepts.
+func (id SteamID) Steam2() string {
+	universe := id.Universe()
+	if universe == UniversePublic {
+		universe = UniverseInvalid
+	}
+	return fmt.Sprintf("STEAM_%d:%d:%d", universe, id.AccountID()&1, id.AccountID()>>1)
+}
+
+// Steam3 returns the SteamID3 (e.g. [U:1:22202]).
+func (id SteamID) Steam3() string {
+	letter, ok := typeLetters[id.Type()]
+	if !ok {
+		letter = 'i'
+	}
+
+	instance := id.Instance()
+	switch id.Type() {
+	case TypeChat:
+		if instance&ChatInstanceFlagClan != 0 {
+			letter = 'c'
+		} else if instance&ChatInstanceFlagLobby != 0 {
+			letter = 'L'
+		}
+		return fmt.Sprintf("[%c:%d:%d]", letter, id.Universe(), id.AccountID())
+	case TypeAnonGameServer, TypeMultiseat:
+		return fmt.Sprintf("[%c:%d:%d:%d]", letter, id.Universe(), id.AccountID(), instance)
+	case TypeIndividual:
+		if instance != InstanceDesktop {
+			return fmt.Sprintf("[%c:%d:%d:%d]", letter, id.Universe(), id.AccountID(), instance)
+		}
+	}
+	return fmt.Sprintf("[%c:%d:%d]", letter, id.Universe(), id.AccountID())
+}
+
+// UserdataFolder returns the name of the user's folder under
+// Steam/userdata (the account ID, e.g. 22202).
+func (id SteamID) UserdataFolder() string {
+	return strconv.FormatUint(uint64(id.AccountID()), 10)
+}
+
+// ProfileURL returns the community profile of a user
+// (https://steamcommunity.com/profiles/<SteamID64>) or group
+// (https://steamcommunity.com/gid/<SteamID64>); other IDs have none.
+func (id SteamID) ProfileURL() string {
+	switch id.Type() {
+	case TypeIndividual:
+		return "https://steamcommunity.com/profiles/" + id.String()
+	case TypeClan:
+		return "https://steamcommunity.com/gid/" + id.String()
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package steamid
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// MarshalText implements encoding.TextMarshaler (as the SteamID64).
+func (id SteamID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Digits are taken as
+// a SteamID64 as-is (the WebAPI sends "0" for no ID), as is "" (sent for
+// empty elements); anything else must be a valid SteamID2 or SteamID3.
+func (id *SteamID) UnmarshalText(text []byte) error {
+	s := string(bytes.TrimSpace(text))
+	if s == "" {
+		*id = 0
+		return nil
+	}
+	if value, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*id = SteamID(value)
+		return nil
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. SteamIDs are written as strings,
+// as the WebAPI does, since JavaScript cannot hold them as numbers.
+func (id SteamID) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(id.String())), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a string (in any
+// form UnmarshalText does), a number or null.
+func (id *SteamID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return err
+		}
+		return id.UnmarshalText([]byte(s))
+	}
+
+	value, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return &ParseError{Input: string(data), Reason: "not a SteamID64"}
+	}
+	*id = SteamID(value)
+	return nil
+}
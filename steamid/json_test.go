@@ -0,0 +1,55 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package steamid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	const gabe SteamID = 76561197960287930
+
+	tests := []struct {
+		json string
+		want SteamID
+	}{
+		{`"76561197960287930"`, gabe},
+		{`76561197960287930`, gabe},
+		{`"[U:1:22202]"`, gabe},
+		{`"STEAM_0:0:11101"`, gabe},
+		{`"0"`, 0},
+		{`""`, 0},
+		{`"  "`, 0},
+		{`null`, 0},
+	}
+	for _, test := range tests {
+		var id SteamID
+		if err := json.Unmarshal([]byte(test.json), &id); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.json, err)
+			continue
+		}
+		if id != test.want {
+			t.Errorf("%s: got %d, want %d", test.json, uint64(id), uint64(test.want))
+		}
+	}
+
+	for _, input := range []string{`"gabe"`, `"[U:1:"`, `-1`, `"STEAM_0:2:1"`} {
+		var id SteamID
+		if err := json.Unmarshal([]byte(input), &id); err == nil {
+			t.Errorf("%s: got %d, want an error", input, uint64(id))
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	data, err := json.Marshal(SteamID(76561197960287930))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"76561197960287930"` {
+		t.Errorf("got %s", data)
+	}
+}
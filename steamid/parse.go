@@ -0,0 +1,148 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package steamid
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid is matched (via errors.Is) by every *ParseError.
+var ErrInvalid = errors.New("invalid SteamID")
+
+// ParseError is returned when a string is not a valid SteamID.
+type ParseError struct {
+	// The string given
+	Input string
+
+	// What was wrong with it
+	Reason string
+}
+
+// Error implements error.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid SteamID '%s': %s", e.Input, e.Reason)
+}
+
+// Is reports whether target is ErrInvalid.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+var (
+	steam2Pattern = regexp.MustCompile(`^(?i:STEAM)_([0-5]):([01]):([0-9]+)$`)
+	steam3Pattern = regexp.MustCompile(`^([a-zA-Z]):([0-5]):([0-9]+)(?::([0-9]+))?$`)
+)
+
+// Parse parses a SteamID64, SteamID2 or SteamID3, and checks that the
+// result is valid (see SteamID.IsValid).
+func Parse(s string) (SteamID, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return 0, &ParseError{Input: s, Reason: "empty"}
+	case strings.HasPrefix(strings.ToUpper(s), "STEAM_"):
+		return ParseSteam2(s)
+	case s[0] == '[' || (len(s) > 1 && s[1] == ':'):
+		return ParseSteam3(s)
+	}
+	return ParseSteamID64(s)
+}
+
+// ParseSteamID64 parses a SteamID64 (e.g. 76561197960287930).
+func ParseSteamID64(s string) (SteamID, error) {
+	value, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, &ParseError{Input: s, Reason: "not a SteamID64, SteamID2 or SteamID3"}
+	}
+	return checked(s, SteamID(value))
+}
+
+// ParseSteam2 parses a SteamID2 (e.g. STEAM_0:0:11101). Universe 0 is
+// taken to be the public universe.
+func ParseSteam2(s string) (SteamID, error) {
+	match := steam2Pattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, &ParseError{Input: s, Reason: "not a SteamID2"}
+	}
+
+	universe, _ := strconv.ParseUint(match[1], 10, 8)
+	if universe == uint64(UniverseInvalid) {
+		universe = uint64(UniversePublic)
+	}
+	y, _ := strconv.ParseUint(match[2], 10, 32)
+	z, err := strconv.ParseUint(match[3], 10, 31)
+	if err != nil {
+		return 0, &ParseError{Input: s, Reason: "account number out of range"}
+	}
+
+	return checked(s, New(Universe(universe), TypeIndividual, InstanceDesktop, uint32(z<<1|y)))
+}
+
+// ParseSteam3 parses a SteamID3 (e.g. [U:1:22202], [g:1:4], [L:1:123]
+// or [A:1:123:456]); the brackets are optional, but must be paired.
+func ParseSteam3(s string) (SteamID, error) {
+	inner := strings.TrimSpace(s)
+	opened, closed := strings.HasPrefix(inner, "["), strings.HasSuffix(inner, "]")
+	if opened != closed {
+		return 0, &ParseError{Input: s, Reason: "unbalanced brackets"}
+	}
+	if opened {
+		inner = strings.TrimSuffix(strings.TrimPrefix(inner, "["), "]")
+	}
+
+	match := steam3Pattern.FindStringSubmatch(inner)
+	if match == nil {
+		return 0, &ParseError{Input: s, Reason: "not a SteamID3"}
+	}
+
+	universe, _ := strconv.ParseUint(match[2], 10, 8)
+	accountID, err := strconv.ParseUint(match[3], 10, 32)
+	if err != nil {
+		return 0, &ParseError{Input: s, Reason: "account ID out of range"}
+	}
+
+	var accountType AccountType
+	instance := uint32(0)
+	switch letter := match[1][0]; letter {
+	case 'U':
+		accountType, instance = TypeIndividual, InstanceDesktop
+	case 'c':
+		accountType, instance = TypeChat, ChatInstanceFlagClan
+	case 'L':
+		accountType, instance = TypeChat, ChatInstanceFlagLobby
+	default:
+		found := false
+		for t, l := range typeLetters {
+			if l == letter {
+				accountType, found = t, true
+			}
+		}
+		if !found {
+			return 0, &ParseError{Input: s, Reason: fmt.Sprintf("unknown account type '%c'", letter)}
+		}
+	}
+
+	if match[4] != "" {
+		value, err := strconv.ParseUint(match[4], 10, 20)
+		if err != nil {
+			return 0, &ParseError{Input: s, Reason: "instance out of range"}
+		}
+		instance = uint32(value)
+	}
+
+	return checked(s, New(Universe(universe), accountType, instance, uint32(accountID)))
+}
+
+// checked returns id if it is valid, or a *ParseError for s.
+func checked(s string, id SteamID) (SteamID, error) {
+	if !id.IsValid() {
+		return 0, &ParseError{Input: s, Reason: "not a valid account"}
+	}
+	return id, nil
+}
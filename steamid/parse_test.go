@@ -0,0 +1,213 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package steamid
+
+import (
+	"errors"
+	"testing"
+)
+
+const (
+	gabe  SteamID = 76561197960287930  // [U:1:22202]
+	valve SteamID = 103582791429521412 // [g:1:4]
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  SteamID
+	}{
+		// SteamID64
+		{"76561197960287930", gabe},
+		{" 76561197960287930 ", gabe},
+		{"103582791429521412", valve},
+
+		// SteamID2, in either form of the public universe
+		{"STEAM_0:0:11101", gabe},
+		{"STEAM_1:0:11101", gabe},
+		{"steam_0:0:11101", gabe},
+		{"STEAM_0:1:11101", NewIndividual(22203)},
+		{"STEAM_0:1:0", NewIndividual(1)},
+		{"STEAM_0:1:2147483647", NewIndividual(0xFFFFFFFF)},
+		{"STEAM_2:0:11101", New(UniverseBeta, TypeIndividual, InstanceDesktop, 22202)},
+
+		// SteamID3, with and without brackets
+		{"[U:1:22202]", gabe},
+		{"U:1:22202", gabe},
+		{"[U:1:22202:4]", New(UniversePublic, TypeIndividual, InstanceWeb, 22202)},
+		{"[U:1:4294967295]", NewIndividual(0xFFFFFFFF)},
+		{"[g:1:4]", valve},
+		{"[G:1:123]", New(UniversePublic, TypeGameServer, 0, 123)},
+		{"[A:1:123:456]", New(UniversePublic, TypeAnonGameServer, 456, 123)},
+		{"[T:1:123]", New(UniversePublic, TypeChat, 0, 123)},
+		{"[c:1:4]", New(UniversePublic, TypeChat, ChatInstanceFlagClan, 4)},
+		{"[L:1:123]", NewLobby(123)},
+		{"[a:1:123]", New(UniversePublic, TypeAnonUser, 0, 123)},
+		{"[M:1:123:7]", New(UniversePublic, TypeMultiseat, 7, 123)},
+		{"[U:4:22202]", New(UniverseDev, TypeIndividual, InstanceDesktop, 22202)},
+	}
+
+	for _, test := range tests {
+		got, err := Parse(test.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.input, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q: got %d (%s), want %d (%s)", test.input, uint64(got), got.Steam3(), uint64(test.want), test.want.Steam3())
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"   ",
+		"gabe",
+		"76561197960287930x",
+		"-76561197960287930",
+		"18446744073709551616", // overflows 64 bits
+		"0",                    // universe 0
+		"76561197960265728",    // account 0
+		"STEAM_0:2:11101",      // Y is 0 or 1
+		"STEAM_6:0:11101",      // universe out of range
+		"STEAM_0:0:2147483648", // overflows 31 bits
+		"STEAM_0:0:",           // no account number
+		"STEAM_0:0:0",          // account 0
+		"[U:1:22202",           // unbalanced
+		"U:1:22202]",           // unbalanced
+		"[]",                   // empty
+		"[U:1]",                // no account ID
+		"[U:1:22202:]",         // empty instance
+		"[U:0:22202]",          // universe 0
+		"[U:5:22202]",          // universe out of range
+		"[U:1:4294967296]",     // overflows 32 bits
+		"[U:1:0]",              // account 0
+		"[U:1:22202:5]",        // individual instance out of range
+		"[U:1:22202:1048576]",  // instance overflows 20 bits
+		"[g:1:4:1]",            // clans have no instance
+		"[X:1:22202]",          // unknown letter
+		"[I:1:22202]",          // invalid account type
+		"[[U:1:22202]]",        // doubled brackets
+		"[U:1:22202] trailing", // trailing text
+	} {
+		if got, err := Parse(input); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q: got %d, %v, want ErrInvalid", input, uint64(got), err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		id     SteamID
+		steam2 string
+		steam3 string
+	}{
+		{gabe, "STEAM_0:0:11101", "[U:1:22202]"},
+		{NewIndividual(22203), "STEAM_0:1:11101", "[U:1:22203]"},
+		{NewIndividual(0xFFFFFFFF), "STEAM_0:1:2147483647", "[U:1:4294967295]"},
+		{New(UniversePublic, TypeIndividual, InstanceWeb, 22202), "STEAM_0:0:11101", "[U:1:22202:4]"},
+		{New(UniverseBeta, TypeIndividual, InstanceDesktop, 22202), "STEAM_2:0:11101", "[U:2:22202]"},
+		{valve, "STEAM_0:0:2", "[g:1:4]"},
+		{New(UniversePublic, TypeGameServer, 0, 123), "STEAM_0:1:61", "[G:1:123]"},
+		{New(UniversePublic, TypeAnonGameServer, 456, 123), "STEAM_0:1:61", "[A:1:123:456]"},
+		{New(UniversePublic, TypeChat, 0, 123), "STEAM_0:1:61", "[T:1:123]"},
+		{valve.ClanChat(), "STEAM_0:0:2", "[c:1:4]"},
+		{NewLobby(123), "STEAM_0:1:61", "[L:1:123]"},
+		{New(UniversePublic, TypeAnonUser, 0, 123), "STEAM_0:1:61", "[a:1:123]"},
+	}
+
+	for _, test := range tests {
+		if got := test.id.Steam2(); got != test.steam2 {
+			t.Errorf("%d: Steam2() = %s, want %s", uint64(test.id), got, test.steam2)
+		}
+		if got := test.id.Steam3(); got != test.steam3 {
+			t.Errorf("%d: Steam3() = %s, want %s", uint64(test.id), got, test.steam3)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	ids := []SteamID{
+		gabe,
+		NewIndividual(1),
+		NewIndividual(0xFFFFFFFF),
+		New(UniversePublic, TypeIndividual, InstanceConsole, 22202),
+		New(UniverseDev, TypeIndividual, InstanceDesktop, 22202),
+		valve,
+		valve.ClanChat(),
+		NewLobby(123),
+		New(UniversePublic, TypeGameServer, 0, 123),
+		New(UniversePublic, TypeAnonGameServer, 456, 123),
+		New(UniversePublic, TypeChat, 0, 123),
+		New(UniversePublic, TypeAnonUser, 0, 123),
+	}
+
+	for _, id := range ids {
+		for _, text := range []string{id.String(), id.Steam3()} {
+			got, err := Parse(text)
+			if err != nil || got != id {
+				t.Errorf("%s: got %d, %v, want %d", text, uint64(got), err, uint64(id))
+			}
+		}
+
+		// SteamID2 only holds public desktop individuals exactly
+		if id.IsIndividual() && id.Instance() == InstanceDesktop {
+			if got, err := Parse(id.Steam2()); err != nil || got != id {
+				t.Errorf("%s: got %d, %v, want %d", id.Steam2(), uint64(got), err, uint64(id))
+			}
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		id   SteamID
+		want bool
+	}{
+		{gabe, true},
+		{valve, true},
+		{0, false},
+		{New(UniverseInvalid, TypeIndividual, InstanceDesktop, 22202), false},
+		{New(UniverseDev+1, TypeIndividual, InstanceDesktop, 22202), false},
+		{New(UniversePublic, TypeInvalid, 0, 22202), false},
+		{NewIndividual(0), false},
+		{New(UniversePublic, TypeIndividual, InstanceWeb, 22202), true},
+		{New(UniversePublic, TypeIndividual, InstanceWeb+1, 22202), false},
+		{NewClan(0), false},
+		{New(UniversePublic, TypeClan, 1, 4), false},
+		{New(UniversePublic, TypeGameServer, 0, 0), false},
+		{New(UniversePublic, TypeAnonGameServer, 0, 0), true},
+		{NewLobby(0), true},
+		{New(UniversePublic, TypeAnonUser+1, 0, 1), false},
+	}
+
+	for _, test := range tests {
+		if got := test.id.IsValid(); got != test.want {
+			t.Errorf("%d (%s): IsValid() = %v, want %v", uint64(test.id), test.id.Steam3(), got, test.want)
+		}
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	if gabe.Universe() != UniversePublic || gabe.Type() != TypeIndividual || gabe.Instance() != InstanceDesktop || gabe.AccountID() != 22202 {
+		t.Errorf("got %s %s %d %d", gabe.Universe(), gabe.Type(), gabe.Instance(), gabe.AccountID())
+	}
+	if !valve.IsClan() || valve.ClanChat().Clan() != valve || !valve.ClanChat().IsClanChat() {
+		t.Error("clan chat conversion failed")
+	}
+	if !NewLobby(123).IsLobby() || gabe.IsLobby() {
+		t.Error("IsLobby failed")
+	}
+	if got := gabe.ProfileURL(); got != "https://steamcommunity.com/profiles/76561197960287930" {
+		t.Errorf("ProfileURL() = %s", got)
+	}
+	if got := valve.ProfileURL(); got != "https://steamcommunity.com/gid/103582791429521412" {
+		t.Errorf("ProfileURL() = %s", got)
+	}
+	if got := gabe.UserdataFolder(); got != "22202" {
+		t.Errorf("UserdataFolder() = %s", got)
+	}
+}
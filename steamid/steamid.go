@@ -0,0 +1,204 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+// Package steamid parses, formats and converts SteamIDs.
+//
+// A SteamID packs four values into 64 bits:
+//
+//	bits 56-63  universe
+//	bits 52-55  account type
+//	bits 32-51  instance
+//	bits  0-31  account ID
+//
+// They are written as a SteamID64 (76561197960287930), a SteamID2
+// (STEAM_0:0:11101) or a SteamID3 ([U:1:22202]).
+package steamid
+
+import (
+	"fmt"
+)
+
+// SteamID is a 64-bit SteamID.
+type SteamID uint64
+
+// Universe is the Steam universe an ID belongs to.
+type Universe uint8
+
+// The universes.
+const (
+	UniverseInvalid  Universe = 0
+	UniversePublic   Universe = 1
+	UniverseBeta     Universe = 2
+	UniverseInternal Universe = 3
+	UniverseDev      Universe = 4
+)
+
+var universeNames = []string{"Invalid", "Public", "Beta", "Internal", "Dev"}
+
+// String returns the name of the universe.
+func (u Universe) String() string {
+	if int(u) < len(universeNames) {
+		return universeNames[u]
+	}
+	return fmt.Sprintf("Universe(%d)", u)
+}
+
+// AccountType is the kind of account an ID refers to.
+type AccountType uint8
+
+// The account types.
+const (
+	TypeInvalid        AccountType = 0
+	TypeIndividual     AccountType = 1
+	TypeMultiseat      AccountType = 2
+	TypeGameServer     AccountType = 3
+	TypeAnonGameServer AccountType = 4
+	TypePending        AccountType = 5
+	TypeContentServer  AccountType = 6
+	TypeClan           AccountType = 7
+	TypeChat           AccountType = 8
+	TypeConsoleUser    AccountType = 9
+	TypeAnonUser       AccountType = 10
+)
+
+var typeNames = []string{
+	"Invalid", "Individual", "Multiseat", "GameServer", "AnonGameServer",
+	"Pending", "ContentServer", "Clan", "Chat", "ConsoleUser", "AnonUser",
+}
+
+// String returns the name of the account type.
+func (t AccountType) String() string {
+	if int(t) < len(typeNames) {
+		return typeNames[t]
+	}
+	return fmt.Sprintf("AccountType(%d)", t)
+}
+
+// Instances of individual accounts.
+const (
+	InstanceAll     uint32 = 0
+	InstanceDesktop uint32 = 1
+	InstanceConsole uint32 = 2
+	InstanceWeb     uint32 = 4
+)
+
+// Instance flags of chat IDs.
+const (
+	// The chat room of a clan (group)
+	ChatInstanceFlagClan uint32 = 0x80000
+
+	// A matchmaking lobby
+	ChatInstanceFlagLobby uint32 = 0x40000
+
+	// A matchmaking service lobby
+	ChatInstanceFlagMMSLobby uint32 = 0x20000
+)
+
+const (
+	accountIDMask = 0xFFFFFFFF
+	instanceMask  = 0xFFFFF
+)
+
+// New builds a SteamID from its parts.
+func New(universe Universe, accountType AccountType, instance uint32, accountID uint32) SteamID {
+	return SteamID(uint64(universe)<<56 |
+		uint64(accountType&0xF)<<52 |
+		uint64(instance&instanceMask)<<32 |
+		uint64(accountID))
+}
+
+// NewIndividual returns the public SteamID of a user's account ID (as
+// used by userdata folders and SteamID3).
+func NewIndividual(accountID uint32) SteamID {
+	return New(UniversePublic, TypeIndividual, InstanceDesktop, accountID)
+}
+
+// NewClan returns the public SteamID of a clan (group) account ID.
+func NewClan(accountID uint32) SteamID {
+	return New(UniversePublic, TypeClan, 0, accountID)
+}
+
+// NewLobby returns the public SteamID of a matchmaking lobby.
+func NewLobby(accountID uint32) SteamID {
+	return New(UniversePublic, TypeChat, ChatInstanceFlagLobby, accountID)
+}
+
+// AccountID returns the account ID (the low 32 bits).
+func (id SteamID) AccountID() uint32 {
+	return uint32(id & accountIDMask)
+}
+
+// Instance returns the instance.
+func (id SteamID) Instance() uint32 {
+	return uint32(id>>32) & instanceMask
+}
+
+// Type returns the account type.
+func (id SteamID) Type() AccountType {
+	return AccountType(id>>52) & 0xF
+}
+
+// Universe returns the universe.
+func (id SteamID) Universe() Universe {
+	return Universe(id >> 56)
+}
+
+// IsIndividual reports whether the ID is a user's.
+func (id SteamID) IsIndividual() bool {
+	return id.Type() == TypeIndividual
+}
+
+// IsClan reports whether the ID is a clan's (group's).
+func (id SteamID) IsClan() bool {
+	return id.Type() == TypeClan
+}
+
+// IsLobby reports whether the ID is a matchmaking lobby's.
+func (id SteamID) IsLobby() bool {
+	return id.Type() == TypeChat && id.Instance()&(ChatInstanceFlagLobby|ChatInstanceFlagMMSLobby) != 0
+}
+
+// IsClanChat reports whether the ID is the chat room of a clan.
+func (id SteamID) IsClanChat() bool {
+	return id.Type() == TypeChat && id.Instance()&ChatInstanceFlagClan != 0
+}
+
+// ClanChat returns the chat room of a clan (or the ID unchanged, if it
+// isn't a clan's).
+func (id SteamID) ClanChat() SteamID {
+	if !id.IsClan() {
+		return id
+	}
+	return New(id.Universe(), TypeChat, ChatInstanceFlagClan, id.AccountID())
+}
+
+// Clan returns the clan a clan chat room belongs to (or the ID unchanged,
+// if it isn't a clan chat).
+func (id SteamID) Clan() SteamID {
+	if !id.IsClanChat() {
+		return id
+	}
+	return New(id.Universe(), TypeClan, 0, id.AccountID())
+}
+
+// IsValid reports whether the ID could belong to an account: the universe
+// and type must be known, and individual, clan and game server IDs must
+// have an account ID (and a valid instance).
+func (id SteamID) IsValid() bool {
+	if id.Universe() == UniverseInvalid || id.Universe() > UniverseDev {
+		return false
+	}
+
+	switch id.Type() {
+	case TypeInvalid:
+		return false
+	case TypeIndividual:
+		return id.AccountID() != 0 && id.Instance() <= InstanceWeb
+	case TypeClan:
+		return id.AccountID() != 0 && id.Instance() == 0
+	case TypeGameServer:
+		return id.AccountID() != 0
+	}
+	return id.Type() <= TypeAnonUser
+}
@@ -0,0 +1,74 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+//
+// This file has been autogenerated using
+// github.com/awstanley/GoSteam/webapi/apps/go-steam-webapi-updater
+
+package ISteamUser
+
+import (
+	"context"
+
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// GetFriendListV1 represents an object capable of calling
+// ISteamUser/GetFriendList/v1/ on the SteamAPI.
+
+type GetFriendListV1 struct {
+	// relationship type (ex: friend)
+	Relationship *string `steam:"relationship,optional"`
+	// SteamID of user
+	Steamid steamid.SteamID `steam:"steamid,nonzero"`
+}
+
+// NewGetFriendListV1 creates a GetFriendListV1 from its required parameters;
+// optional ones are set with its Set methods.
+func NewGetFriendListV1(steamidParam steamid.SteamID) *GetFriendListV1 {
+	return &GetFriendListV1{
+		Steamid: steamidParam,
+	}
+}
+
+// SetRelationship sets the optional relationship parameter.
+func (method *GetFriendListV1) SetRelationship(value string) *GetFriendListV1 {
+	method.Relationship = &value
+	return method
+}
+
+// Call creates a query from GetFriendListV1, and subsequently calls it
+// using the GET method type.
+//
+// This is ISteamUser/GetFriendList/v1/ of the SteamAPI.
+func (method *GetFriendListV1) Call(conn *core.Connection) (contents []byte, err error) {
+	return method.CallContext(context.Background(), conn)
+}
+
+// CallContext is Call bound to ctx; cancelling ctx aborts the request.
+func (method *GetFriendListV1) CallContext(ctx context.Context, conn *core.Connection) (contents []byte, err error) {
+	request, err := method.Request(conn)
+	if err != nil {
+		return nil, err
+	}
+	return conn.Do(ctx, request)
+}
+
+// Validate checks GetFriendListV1 for missing required parameters, and
+// values which cannot be sent, without sending anything.
+func (method *GetFriendListV1) Validate() error {
+	return core.Validate(method)
+}
+
+// Request validates and builds the request Call sends, without sending it.
+func (method *GetFriendListV1) Request(conn *core.Connection) (*core.Request, error) {
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := core.EncodeParameters(method)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewRequest("GET", "ISteamUser/GetFriendList/v1/", params, true), nil
+}
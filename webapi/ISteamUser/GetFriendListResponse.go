@@ -0,0 +1,36 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package ISteamUser
+
+import (
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// Friend is a single entry returned by GetFriendList.
+type Friend struct {
+	SteamID core.SteamID `json:"steamid"`
+
+	// e.g. "friend"
+	Relationship string `json:"relationship"`
+
+	FriendSince core.UnixTime `json:"friend_since"`
+}
+
+// GetFriendListV1Response represents the JSON return value.
+//
+// Private friend lists fail with a 401 (see core.IsUnauthorized).
+type GetFriendListV1Response struct {
+	Friends []Friend `json:"friends"`
+}
+
+// Decode transforms the raw byte content (JSON) into a neat struct.
+func (res *GetFriendListV1Response) Decode(contents []byte) error {
+	return res.DecodeFormat(core.FormatJSON, contents)
+}
+
+// DecodeFormat transforms raw byte content in the given format into a neat struct.
+func (res *GetFriendListV1Response) DecodeFormat(format core.Format, contents []byte) error {
+	return core.DecodeResponse(format, contents, res)
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+//
+// This file has been autogenerated using
+// github.com/awstanley/GoSteam/webapi/apps/go-steam-webapi-updater
+
+package ISteamUser
+
+import (
+	"context"
+
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// GetPlayerBansV1 represents an object capable of calling
+// ISteamUser/GetPlayerBans/v1/ on the SteamAPI.
+
+type GetPlayerBansV1 struct {
+	// Comma-delimited list of SteamIDs
+	Steamids []steamid.SteamID `steam:"steamids,list,nonzero"`
+}
+
+// NewGetPlayerBansV1 creates a GetPlayerBansV1 from its required parameters;
+// optional ones are set with its Set methods.
+func NewGetPlayerBansV1(steamids []steamid.SteamID) *GetPlayerBansV1 {
+	return &GetPlayerBansV1{
+		Steamids: steamids,
+	}
+}
+
+// Call creates a query from GetPlayerBansV1, and subsequently calls it
+// using the GET method type.
+//
+// This is ISteamUser/GetPlayerBans/v1/ of the SteamAPI.
+func (method *GetPlayerBansV1) Call(conn *core.Connection) (contents []byte, err error) {
+	return method.CallContext(context.Background(), conn)
+}
+
+// CallContext is Call bound to ctx; cancelling ctx aborts the request.
+func (method *GetPlayerBansV1) CallContext(ctx context.Context, conn *core.Connection) (contents []byte, err error) {
+	request, err := method.Request(conn)
+	if err != nil {
+		return nil, err
+	}
+	return conn.Do(ctx, request)
+}
+
+// Validate checks GetPlayerBansV1 for missing required parameters, and
+// values which cannot be sent, without sending anything.
+func (method *GetPlayerBansV1) Validate() error {
+	return core.Validate(method)
+}
+
+// Request validates and builds the request Call sends, without sending it.
+func (method *GetPlayerBansV1) Request(conn *core.Connection) (*core.Request, error) {
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := core.EncodeParameters(method)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewRequest("GET", "ISteamUser/GetPlayerBans/v1/", params, true), nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package ISteamUser
+
+import (
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// PlayerBans is the ban record of a single player returned by GetPlayerBans.
+type PlayerBans struct {
+	SteamID          core.SteamID `json:"SteamId"`
+	CommunityBanned  bool         `json:"CommunityBanned"`
+	VACBanned        bool         `json:"VACBanned"`
+	NumberOfVACBans  int          `json:"NumberOfVACBans"`
+	DaysSinceLastBan int          `json:"DaysSinceLastBan"`
+	NumberOfGameBans int          `json:"NumberOfGameBans"`
+
+	// "none", "probation" or "banned"
+	EconomyBan string `json:"EconomyBan"`
+}
+
+// GetPlayerBansV1Response represents the JSON return value.
+type GetPlayerBansV1Response struct {
+	Players []PlayerBans `json:"players"`
+}
+
+// Decode transforms the raw byte content (JSON) into a neat struct.
+func (res *GetPlayerBansV1Response) Decode(contents []byte) error {
+	return res.DecodeFormat(core.FormatJSON, contents)
+}
+
+// DecodeFormat transforms raw byte content in the given format into a neat struct.
+func (res *GetPlayerBansV1Response) DecodeFormat(format core.Format, contents []byte) error {
+	return core.DecodeResponse(format, contents, res)
+}
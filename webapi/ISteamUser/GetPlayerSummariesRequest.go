@@ -0,0 +1,66 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+//
+// This file has been autogenerated using
+// github.com/awstanley/GoSteam/webapi/apps/go-steam-webapi-updater
+
+package ISteamUser
+
+import (
+	"context"
+
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// GetPlayerSummariesV2 represents an object capable of calling
+// ISteamUser/GetPlayerSummaries/v2/ on the SteamAPI.
+
+type GetPlayerSummariesV2 struct {
+	// Comma-delimited list of SteamIDs (max: 100)
+	Steamids []steamid.SteamID `steam:"steamids,list,nonzero"`
+}
+
+// NewGetPlayerSummariesV2 creates a GetPlayerSummariesV2 from its required parameters;
+// optional ones are set with its Set methods.
+func NewGetPlayerSummariesV2(steamids []steamid.SteamID) *GetPlayerSummariesV2 {
+	return &GetPlayerSummariesV2{
+		Steamids: steamids,
+	}
+}
+
+// Call creates a query from GetPlayerSummariesV2, and subsequently calls it
+// using the GET method type.
+//
+// This is ISteamUser/GetPlayerSummaries/v2/ of the SteamAPI.
+func (method *GetPlayerSummariesV2) Call(conn *core.Connection) (contents []byte, err error) {
+	return method.CallContext(context.Background(), conn)
+}
+
+// CallContext is Call bound to ctx; cancelling ctx aborts the request.
+func (method *GetPlayerSummariesV2) CallContext(ctx context.Context, conn *core.Connection) (contents []byte, err error) {
+	request, err := method.Request(conn)
+	if err != nil {
+		return nil, err
+	}
+	return conn.Do(ctx, request)
+}
+
+// Validate checks GetPlayerSummariesV2 for missing required parameters, and
+// values which cannot be sent, without sending anything.
+func (method *GetPlayerSummariesV2) Validate() error {
+	return core.Validate(method)
+}
+
+// Request validates and builds the request Call sends, without sending it.
+func (method *GetPlayerSummariesV2) Request(conn *core.Connection) (*core.Request, error) {
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := core.EncodeParameters(method)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewRequest("GET", "ISteamUser/GetPlayerSummaries/v2/", params, true), nil
+}
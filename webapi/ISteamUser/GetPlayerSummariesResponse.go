@@ -0,0 +1,68 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package ISteamUser
+
+import (
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// PlayerSummary is a single player returned by GetPlayerSummaries.
+//
+// Private profiles only include the public fields (SteamID through
+// PersonaState); the rest are left zero.
+type PlayerSummary struct {
+	SteamID core.SteamID `json:"steamid"`
+
+	// 1 private (or friends only), 3 public
+	CommunityVisibilityState int `json:"communityvisibilitystate"`
+
+	// True if the user has set up a community profile
+	ProfileState core.IntBool `json:"profilestate"`
+
+	PersonaName string `json:"personaname"`
+
+	// True if the profile allows public comments
+	CommentPermission core.IntBool `json:"commentpermission"`
+
+	ProfileURL   string        `json:"profileurl"`
+	Avatar       string        `json:"avatar"`
+	AvatarMedium string        `json:"avatarmedium"`
+	AvatarFull   string        `json:"avatarfull"`
+	AvatarHash   string        `json:"avatarhash"`
+	LastLogoff   core.UnixTime `json:"lastlogoff"`
+
+	// 0 offline, 1 online, 2 busy, 3 away, 4 snooze, 5 looking to trade,
+	// 6 looking to play
+	PersonaState int `json:"personastate"`
+
+	RealName          string        `json:"realname"`
+	PrimaryClanID     core.SteamID  `json:"primaryclanid"`
+	TimeCreated       core.UnixTime `json:"timecreated"`
+	PersonaStateFlags int           `json:"personastateflags"`
+
+	// The game being played (if any)
+	GameExtraInfo string            `json:"gameextrainfo"`
+	GameID        core.StringUint64 `json:"gameid"`
+	GameServerIP  string            `json:"gameserverip"`
+
+	LocCountryCode string `json:"loccountrycode"`
+	LocStateCode   string `json:"locstatecode"`
+	LocCityID      int    `json:"loccityid"`
+}
+
+// GetPlayerSummariesV2Response represents the JSON return value.
+type GetPlayerSummariesV2Response struct {
+	Players []PlayerSummary `json:"players"`
+}
+
+// Decode transforms the raw byte content (JSON) into a neat struct.
+func (res *GetPlayerSummariesV2Response) Decode(contents []byte) error {
+	return res.DecodeFormat(core.FormatJSON, contents)
+}
+
+// DecodeFormat transforms raw byte content in the given format into a neat struct.
+func (res *GetPlayerSummariesV2Response) DecodeFormat(format core.Format, contents []byte) error {
+	return core.DecodeResponse(format, contents, res)
+}
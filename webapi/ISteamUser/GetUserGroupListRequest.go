@@ -0,0 +1,66 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+//
+// This file has been autogenerated using
+// github.com/awstanley/GoSteam/webapi/apps/go-steam-webapi-updater
+
+package ISteamUser
+
+import (
+	"context"
+
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// GetUserGroupListV1 represents an object capable of calling
+// ISteamUser/GetUserGroupList/v1/ on the SteamAPI.
+
+type GetUserGroupListV1 struct {
+	// SteamID of user
+	Steamid steamid.SteamID `steam:"steamid,nonzero"`
+}
+
+// NewGetUserGroupListV1 creates a GetUserGroupListV1 from its required parameters;
+// optional ones are set with its Set methods.
+func NewGetUserGroupListV1(steamidParam steamid.SteamID) *GetUserGroupListV1 {
+	return &GetUserGroupListV1{
+		Steamid: steamidParam,
+	}
+}
+
+// Call creates a query from GetUserGroupListV1, and subsequently calls it
+// using the GET method type.
+//
+// This is ISteamUser/GetUserGroupList/v1/ of the SteamAPI.
+func (method *GetUserGroupListV1) Call(conn *core.Connection) (contents []byte, err error) {
+	return method.CallContext(context.Background(), conn)
+}
+
+// CallContext is Call bound to ctx; cancelling ctx aborts the request.
+func (method *GetUserGroupListV1) CallContext(ctx context.Context, conn *core.Connection) (contents []byte, err error) {
+	request, err := method.Request(conn)
+	if err != nil {
+		return nil, err
+	}
+	return conn.Do(ctx, request)
+}
+
+// Validate checks GetUserGroupListV1 for missing required parameters, and
+// values which cannot be sent, without sending anything.
+func (method *GetUserGroupListV1) Validate() error {
+	return core.Validate(method)
+}
+
+// Request validates and builds the request Call sends, without sending it.
+func (method *GetUserGroupListV1) Request(conn *core.Connection) (*core.Request, error) {
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := core.EncodeParameters(method)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewRequest("GET", "ISteamUser/GetUserGroupList/v1/", params, true), nil
+}
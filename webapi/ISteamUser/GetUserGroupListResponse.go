@@ -0,0 +1,37 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package ISteamUser
+
+import (
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// Group is a single group returned by GetUserGroupList.
+type Group struct {
+	// The group's account ID
+	GID core.StringUint64 `json:"gid"`
+}
+
+// SteamID returns the group's SteamID.
+func (g Group) SteamID() steamid.SteamID {
+	return steamid.NewClan(uint32(g.GID))
+}
+
+// GetUserGroupListV1Response represents the JSON return value.
+type GetUserGroupListV1Response struct {
+	Groups []Group `json:"groups"`
+}
+
+// Decode transforms the raw byte content (JSON) into a neat struct.
+// A failed lookup (e.g. a private profile) returns a *core.ResultError.
+func (res *GetUserGroupListV1Response) Decode(contents []byte) error {
+	return res.DecodeFormat(core.FormatJSON, contents)
+}
+
+// DecodeFormat transforms raw byte content in the given format into a neat struct.
+func (res *GetUserGroupListV1Response) DecodeFormat(format core.Format, contents []byte) error {
+	return core.DecodeResponse(format, contents, res)
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+//
+// This file has been autogenerated using
+// github.com/awstanley/GoSteam/webapi/apps/go-steam-webapi-updater
+
+package ISteamUser
+
+import (
+	"context"
+
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// ResolveVanityURLV1 represents an object capable of calling
+// ISteamUser/ResolveVanityURL/v1/ on the SteamAPI.
+
+type ResolveVanityURLV1 struct {
+	// The type of vanity URL. 1 (default): Individual profile, 2: Group, 3: Official game group
+	UrlType *int32 `steam:"url_type,optional"`
+	// The vanity URL to get a SteamID for
+	Vanityurl string `steam:"vanityurl"`
+}
+
+// NewResolveVanityURLV1 creates a ResolveVanityURLV1 from its required parameters;
+// optional ones are set with its Set methods.
+func NewResolveVanityURLV1(vanityurl string) *ResolveVanityURLV1 {
+	return &ResolveVanityURLV1{
+		Vanityurl: vanityurl,
+	}
+}
+
+// SetUrlType sets the optional url_type parameter.
+func (method *ResolveVanityURLV1) SetUrlType(value int32) *ResolveVanityURLV1 {
+	method.UrlType = &value
+	return method
+}
+
+// Call creates a query from ResolveVanityURLV1, and subsequently calls it
+// using the GET method type.
+//
+// This is ISteamUser/ResolveVanityURL/v1/ of the SteamAPI.
+func (method *ResolveVanityURLV1) Call(conn *core.Connection) (contents []byte, err error) {
+	return method.CallContext(context.Background(), conn)
+}
+
+// CallContext is Call bound to ctx; cancelling ctx aborts the request.
+func (method *ResolveVanityURLV1) CallContext(ctx context.Context, conn *core.Connection) (contents []byte, err error) {
+	request, err := method.Request(conn)
+	if err != nil {
+		return nil, err
+	}
+	return conn.Do(ctx, request)
+}
+
+// Validate checks ResolveVanityURLV1 for missing required parameters, and
+// values which cannot be sent, without sending anything.
+func (method *ResolveVanityURLV1) Validate() error {
+	return core.Validate(method)
+}
+
+// Request validates and builds the request Call sends, without sending it.
+func (method *ResolveVanityURLV1) Request(conn *core.Connection) (*core.Request, error) {
+	if err := method.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := core.EncodeParameters(method)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewRequest("GET", "ISteamUser/ResolveVanityURL/v1/", params, true), nil
+}
@@ -5,60 +5,23 @@
 package ISteamUser
 
 import (
-	"encoding/json"
-	"fmt"
-	"strconv"
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
 )
 
 // ResolveVanityURLV1Response respresents the JSON return value.
 type ResolveVanityURLV1Response struct {
-	SteamID uint64
-	Success int
+	SteamID steamid.SteamID `json:"steamid"`
+	Success int             `json:"success"`
 }
 
-// Decode transforms the raw byte content into a neat struct.
+// Decode transforms the raw byte content (JSON) into a neat struct.
 func (res *ResolveVanityURLV1Response) Decode(contents []byte) error {
-	type SuccessInner struct {
-		SteamID string `json:"steamid"`
-		Success int    `json:"success"`
-	}
-
-	type SuccessResponse struct {
-		InnerStruct SuccessInner `json:"response"`
-	}
-
-	type FailInner struct {
-		Success int    `json:"success"`
-		Message string `json:"message"`
-	}
-
-	type FailResponse struct {
-		InnerStruct FailInner `json:"response"`
-	}
-
-	response := SuccessResponse{}
-
-	err := json.Unmarshal(contents, &response)
-
-	// Catch the failure state before it gets out of hand.
-	if err != nil || response.InnerStruct.Success != 1 {
-		failure := FailResponse{}
-		err = json.Unmarshal(contents, &failure)
-		if err != nil {
-			return err // this is *bad*
-		}
-
-		// Failure fallback
-		return fmt.Errorf("query returned with non-success value (%d) and message '%s'\n",
-			failure.InnerStruct.Success,
-			failure.InnerStruct.Message,
-		)
-	}
+	return res.DecodeFormat(core.FormatJSON, contents)
+}
 
-	res.SteamID, err = strconv.ParseUint(response.InnerStruct.SteamID, 10, 64)
-	if err != nil {
-		return err
-	}
-	res.Success = response.InnerStruct.Success
-	return nil
+// DecodeFormat transforms raw byte content in the given format into a neat struct.
+// A failed lookup (e.g. no match) returns a *core.ResultError.
+func (res *ResolveVanityURLV1Response) DecodeFormat(format core.Format, contents []byte) error {
+	return core.DecodeResponse(format, contents, res)
 }
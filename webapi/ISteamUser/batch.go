@@ -0,0 +1,142 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package ISteamUser
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// MaxSteamIDsPerCall is the most SteamIDs GetPlayerSummaries and
+// GetPlayerBans accept in one call.
+const MaxSteamIDsPerCall = 100
+
+// DefaultBatchConcurrency is the number of calls the batch helpers run at
+// once when given a concurrency of zero.
+const DefaultBatchConcurrency = 4
+
+// GetPlayerSummariesBatch returns the summaries of any number of players,
+// calling GetPlayerSummaries (v2) for each 100 at a time, with up to
+// concurrency calls at once. The summaries are in the order their IDs
+// first appear in ids; repeated IDs are only asked for once, and players
+// Steam doesn't know are left out.
+func GetPlayerSummariesBatch(ctx context.Context, conn *core.Connection, ids []steamid.SteamID, concurrency int) ([]PlayerSummary, error) {
+	key := func(player PlayerSummary) steamid.SteamID { return player.SteamID }
+	return batch(ctx, ids, concurrency, key, func(ctx context.Context, chunk []steamid.SteamID) ([]PlayerSummary, error) {
+		request, err := NewGetPlayerSummariesV2(chunk).Request(conn)
+		if err != nil {
+			return nil, err
+		}
+		response, err := core.CallAndDecode[GetPlayerSummariesV2Response](ctx, conn, request)
+		return response.Players, err
+	})
+}
+
+// GetPlayerBansBatch returns the ban records of any number of players,
+// calling GetPlayerBans (v1) for each 100 at a time, with up to
+// concurrency calls at once. The records are in the order their IDs
+// first appear in ids; repeated IDs are only asked for once.
+func GetPlayerBansBatch(ctx context.Context, conn *core.Connection, ids []steamid.SteamID, concurrency int) ([]PlayerBans, error) {
+	key := func(player PlayerBans) steamid.SteamID { return player.SteamID }
+	return batch(ctx, ids, concurrency, key, func(ctx context.Context, chunk []steamid.SteamID) ([]PlayerBans, error) {
+		request, err := NewGetPlayerBansV1(chunk).Request(conn)
+		if err != nil {
+			return nil, err
+		}
+		response, err := core.CallAndDecode[GetPlayerBansV1Response](ctx, conn, request)
+		return response.Players, err
+	})
+}
+
+// batch splits ids into chunks of MaxSteamIDsPerCall, calls call for each
+// (up to concurrency at once) and merges the results. Steam doesn't
+// answer in the order it is asked, so the merged results are sorted by
+// where their key first appears in ids; any it wasn't asked for go last.
+// The first error cancels the remaining calls and is returned.
+func batch[T any](ctx context.Context, ids []steamid.SteamID, concurrency int, key func(T) steamid.SteamID, call func(ctx context.Context, chunk []steamid.SteamID) ([]T, error)) ([]T, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	position := make(map[steamid.SteamID]int, len(ids))
+	var unique []steamid.SteamID
+	for _, id := range ids {
+		if _, seen := position[id]; !seen {
+			position[id] = len(unique)
+			unique = append(unique, id)
+		}
+	}
+
+	var chunks [][]steamid.SteamID
+	for len(unique) > 0 {
+		n := len(unique)
+		if n > MaxSteamIDsPerCall {
+			n = MaxSteamIDsPerCall
+		}
+		chunks = append(chunks, unique[:n])
+		unique = unique[n:]
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]T, len(chunks))
+	var firstErr error
+	var once sync.Once
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk []steamid.SteamID) {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-slots }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			result, err := call(ctx, chunk)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = result
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var merged []T
+	for _, result := range results {
+		merged = append(merged, result...)
+	}
+	order := func(v T) int {
+		if i, ok := position[key(v)]; ok {
+			return i
+		}
+		return len(position)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return order(merged[i]) < order(merged[j])
+	})
+	return merged, nil
+}
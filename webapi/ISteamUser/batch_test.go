@@ -0,0 +1,254 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package ISteamUser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// playerServer answers GetPlayerSummaries and GetPlayerBans with a
+// record for every SteamID asked for (in reverse order, and leaving out
+// any in unknown), and keeps count of what it was asked.
+type playerServer struct {
+	*httptest.Server
+
+	// Status to fail every request with, if not zero
+	status int
+
+	// How long each request takes
+	delay time.Duration
+
+	// SteamIDs to leave out of the answers
+	unknown map[steamid.SteamID]bool
+
+	mu       sync.Mutex
+	chunks   [][]steamid.SteamID
+	inFlight int
+	peak     int
+}
+
+func newPlayerServer(t *testing.T) *playerServer {
+	s := &playerServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *playerServer) serve(w http.ResponseWriter, r *http.Request) {
+	var chunk []steamid.SteamID
+	for _, field := range strings.Split(r.URL.Query().Get("steamids"), ",") {
+		id, err := steamid.Parse(field)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		chunk = append(chunk, id)
+	}
+
+	s.mu.Lock()
+	s.chunks = append(s.chunks, chunk)
+	s.inFlight++
+	if s.inFlight > s.peak {
+		s.peak = s.inFlight
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+
+	time.Sleep(s.delay)
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		return
+	}
+
+	var players []map[string]string
+	for i := len(chunk) - 1; i >= 0; i-- {
+		if !s.unknown[chunk[i]] {
+			players = append(players, map[string]string{
+				"steamid": chunk[i].String(),
+				"SteamId": chunk[i].String(),
+			})
+		}
+	}
+	if strings.Contains(r.URL.Path, "GetPlayerBans") {
+		json.NewEncoder(w).Encode(map[string]interface{}{"players": players})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"response": map[string]interface{}{"players": players}})
+}
+
+func (s *playerServer) conn() *core.Connection {
+	return core.NewConnectionWithOptions("key", core.WithBaseURI(s.URL))
+}
+
+// players returns n distinct SteamIDs.
+func players(n int) []steamid.SteamID {
+	ids := make([]steamid.SteamID, n)
+	for i := range ids {
+		ids[i] = steamid.NewIndividual(uint32(1000 + i))
+	}
+	return ids
+}
+
+func summaryIDs(summaries []PlayerSummary) []steamid.SteamID {
+	var ids []steamid.SteamID
+	for _, summary := range summaries {
+		ids = append(ids, summary.SteamID)
+	}
+	return ids
+}
+
+func TestGetPlayerSummariesBatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		ids    []steamid.SteamID
+		chunks []int
+		want   []steamid.SteamID
+	}{
+		{"none", nil, nil, nil},
+		{"one", players(1), []int{1}, players(1)},
+		{"one call", players(100), []int{100}, players(100)},
+		{"just over one call", players(101), []int{100, 1}, players(101)},
+		{"several calls", players(250), []int{100, 100, 50}, players(250)},
+		{
+			"duplicates",
+			append(append(players(101), players(3)...), players(101)[100]),
+			[]int{100, 1},
+			players(101),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newPlayerServer(t)
+			got, err := GetPlayerSummariesBatch(context.Background(), server.conn(), test.ids, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var sizes []int
+			asked := map[steamid.SteamID]int{}
+			for _, chunk := range server.chunks {
+				sizes = append(sizes, len(chunk))
+				for _, id := range chunk {
+					asked[id]++
+				}
+			}
+			if len(sizes) != len(test.chunks) {
+				t.Errorf("made %d calls, want %d", len(sizes), len(test.chunks))
+			}
+			for id, n := range asked {
+				if n != 1 {
+					t.Errorf("asked for %d %d times", uint64(id), n)
+				}
+			}
+			if len(asked) != len(test.want) {
+				t.Errorf("asked for %d IDs, want %d", len(asked), len(test.want))
+			}
+
+			// The merged results are in input order, whatever order the
+			// calls answered in
+			if ids := summaryIDs(got); !reflect.DeepEqual(ids, test.want) {
+				t.Errorf("got %d players, want %d in input order", len(ids), len(test.want))
+			}
+		})
+	}
+}
+
+func TestGetPlayerSummariesBatchUnknown(t *testing.T) {
+	ids := players(150)
+	server := newPlayerServer(t)
+	server.unknown = map[steamid.SteamID]bool{ids[0]: true, ids[120]: true}
+
+	got, err := GetPlayerSummariesBatch(context.Background(), server.conn(), ids, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := append(append([]steamid.SteamID{}, ids[1:120]...), ids[121:]...)
+	if !reflect.DeepEqual(summaryIDs(got), want) {
+		t.Errorf("got %d players, want %d in input order", len(got), len(want))
+	}
+}
+
+func TestGetPlayerBansBatch(t *testing.T) {
+	ids := players(250)
+	server := newPlayerServer(t)
+
+	got, err := GetPlayerBansBatch(context.Background(), server.conn(), ids, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("got %d records, want %d", len(got), len(ids))
+	}
+	for i, bans := range got {
+		if bans.SteamID != ids[i] {
+			t.Fatalf("record %d is %d, want %d", i, uint64(bans.SteamID), uint64(ids[i]))
+		}
+	}
+}
+
+func TestBatchConcurrency(t *testing.T) {
+	for _, concurrency := range []int{1, 2, 4} {
+		server := newPlayerServer(t)
+		server.delay = 20 * time.Millisecond
+
+		if _, err := GetPlayerSummariesBatch(context.Background(), server.conn(), players(1000), concurrency); err != nil {
+			t.Fatalf("%d: unexpected error: %v", concurrency, err)
+		}
+		if len(server.chunks) != 10 {
+			t.Errorf("%d: made %d calls, want 10", concurrency, len(server.chunks))
+		}
+		if server.peak > concurrency {
+			t.Errorf("%d: ran %d calls at once", concurrency, server.peak)
+		}
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	server := newPlayerServer(t)
+	server.status = http.StatusForbidden
+	server.delay = 20 * time.Millisecond
+
+	// With one call at a time, the first failure cancels every other call
+	// before it starts
+	got, err := GetPlayerSummariesBatch(context.Background(), server.conn(), players(500), 1)
+	var apiErr *core.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("got %v, want a 403 *core.APIError", err)
+	}
+	if got != nil {
+		t.Errorf("got %d players alongside the error", len(got))
+	}
+	if len(server.chunks) != 1 {
+		t.Errorf("made %d calls, want 1", len(server.chunks))
+	}
+}
+
+func TestBatchCancel(t *testing.T) {
+	server := newPlayerServer(t)
+	server.delay = 50 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := GetPlayerSummariesBatch(ctx, server.conn(), players(500), 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -10,37 +10,37 @@ import (
 	"fmt"
 	"go/format"
 	"io/ioutil"
-	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"text/template"
-)
+	"time"
 
-type Param struct {
-	name      string
-	paramType string
-	required  bool
-}
+	"github.com/awstanley/GoSteam/webapi/core"
+)
 
 var repository string = "github.com/awstanley/GoSteam/webapi"
 
-// Public endpoint
-var publicAPI string = "api.steampowered.com"
-
-// Partner endpoint
-var partnerAPI string = "partner.steam-api.com"
-
 func Usage() {
 	fmt.Println("Usage:")
 	fmt.Printf("%s --key <key>\n\n", os.Args[0])
 	fmt.Println("  --file <file>")
 	fmt.Println("  --partner")
 	fmt.Println("  --insecure")
+	fmt.Println("  --proto <dir> --proto-import <package>")
 }
 
 var tmplRoot string
 
+// Names of the generated methods, which fields must not shadow
+var reservedNames = map[string]bool{
+	"Call":        true,
+	"CallContext": true,
+	"Request":     true,
+	"Validate":    true,
+}
+
 func toPrettyGoName(old string) string {
 	// Make it marginally prettier
 	out := strings.Replace(old, "_", " ", -1)
@@ -49,32 +49,47 @@ func toPrettyGoName(old string) string {
 	return out
 }
 
+// toFieldName returns the struct field name used for a parameter.
+func toFieldName(old string) string {
+	out := toPrettyGoName(old)
+	if reservedNames[out] {
+		out += "Param"
+	}
+	return out
+}
+
 func loadTemplate(name string) *template.Template {
 	return template.Must(template.New(name).ParseFiles(filepath.Clean(fmt.Sprintf("%s/%s", tmplRoot, name))))
 }
 
+// usesJSON reports whether a method is called with input_json: service
+// interfaces take nested messages that way, so those methods encode the
+// whole struct as JSON.
+func usesJSON(interfaceName string, method *apiSteamVersionedMethod) bool {
+	if !strings.HasSuffix(interfaceName, "Service") {
+		return false
+	}
+	for _, p := range method.params {
+		if p.varType == "{message}" {
+			return true
+		}
+	}
+	return false
+}
+
 func main() {
 
 	partner := flag.Bool("partner", false, "If true the partner api endpoint is used.")
 	insecure := flag.Bool("insecure", false, "If true HTTP is used instead of HTTPS.")
 	key := flag.String("key", "", "Steam API Key")
 	localJSON := flag.String("file", "", "JSON file (for local load)")
+	protoDir := flag.String("proto", "", "Directory of .proto files describing the service interfaces")
+	protoImport := flag.String("proto-import", "", "Go import path of the package protoc generated from --proto")
 
 	flag.Usage = Usage
 
 	flag.Parse()
 
-	base := publicAPI
-	if *partner {
-		*insecure = true
-		base = partnerAPI
-	}
-
-	proto := "https://"
-	if *insecure {
-		proto = "http://"
-	}
-
 	// Get the output directory
 	dst := fmt.Sprintf("%s/src/%s", os.Getenv("GOPATH"), repository)
 
@@ -86,33 +101,42 @@ func main() {
 	tmplStruct := loadTemplate("struct.txt")
 	tmplFuncGet := loadTemplate("funcGet.txt")
 	tmplFuncPost := loadTemplate("funcPost.txt")
+	tmplFuncProto := loadTemplate("funcProto.txt")
+
+	// Protobuf services (optional)
+	var protos map[string]map[string]protoRPC
+	if *protoDir != "" {
+		if *protoImport == "" {
+			fmt.Println("--proto requires --proto-import")
+			return
+		}
+		var err error
+		protos, err = loadProtos(*protoDir)
+		if err != nil {
+			fmt.Printf("Failure in loading protobuf definitions\n\terr: %s\n", err)
+			return
+		}
+	}
 
 	var api apiSteam
 
 	if *localJSON == "" {
 
-		uri := fmt.Sprintf("%s%s/ISteamWebAPIUtil/GetSupportedAPIList/v1/", proto, base)
-		if *key != "" {
-			uri = fmt.Sprintf("%s?key=%s", uri, *key)
-		}
+		// The connection keeps the key out of anything printed below
+		conn := core.NewConnectionWithOptions(*key,
+			core.WithSecure(!*insecure),
+			core.WithPartner(*partner),
+			core.WithTimeout(30*time.Second),
+		)
 
-		println(uri)
+		uri := "ISteamWebAPIUtil/GetSupportedAPIList/v1/"
+		fmt.Printf("Fetching %s%s\n", conn.BaseURI(), uri)
 
-		response, err := http.Get(uri)
+		content, err := conn.Get(uri, core.NewParameters(), *key != "")
 		if err != nil {
 			fmt.Printf("Error encountered getting supported list: %s\n", err)
 			return
 		}
-		defer response.Body.Close()
-
-		// This should be safe, given the SteamAPI doesn't typically
-		// return 2GB+ files...
-		//content = make([]byte, response.ContentLength)
-		content, err := ioutil.ReadAll(response.Body)
-		if err != nil {
-			fmt.Printf("Error getting API: %s\n\n", err)
-			return
-		}
 
 		var root jsonSteamSupportedRoot
 
@@ -149,6 +173,7 @@ func main() {
 
 	tmplData := make(map[string]interface{})
 	tmplData["webapi"] = fmt.Sprintf("%s/core", repository)
+	tmplData["steamid"] = fmt.Sprintf("%s/steamid", path.Dir(repository))
 
 	// Now that we have that, we can build GoLang files ...
 	for interfaceName, interfaceObj := range api.interfaces {
@@ -165,6 +190,24 @@ func main() {
 				return
 			}
 
+			// Methods described by the protobuf definitions get Call<Method>Protobuf
+			rpc, hasProto := protos[interfaceName][methodName]
+			tmplData["protoImport"] = ""
+			if hasProto {
+				tmplData["protoImport"] = *protoImport
+				tmplData["protoRequest"] = rpc.request
+				tmplData["protoResponse"] = rpc.response
+			}
+
+			// The steamid package is only imported where it is used
+			tmplData["importSteamID"] = false
+			for _, versionObj := range methodMap.methods {
+				params, _ := buildParams(versionObj, usesJSON(interfaceName, versionObj))
+				if usesSteamID(params) {
+					tmplData["importSteamID"] = true
+				}
+			}
+
 			// File header
 			err = tmplHeader.Execute(fp, tmplData)
 			if err != nil {
@@ -188,69 +231,25 @@ func main() {
 
 				fmt.Fprintf(fp, "\ntype %s struct {\n", tmplMethodName)
 
-				reqParams := make(map[string]*Param)
-				optParams := make(map[string]*Param)
+				useJSON := usesJSON(interfaceName, versionObj)
+				params, requiresKey := buildParams(versionObj, useJSON)
+				for _, v := range params {
+					if v.countOf != nil {
+						// Filled in from the array's length
+						continue
+					}
 
-				typeString := ""
-				requiresKey := false
-				for _, p := range versionObj.params {
-					if p.name == "key" {
-						requiresKey = true
+					if v.description == "" {
+						fmt.Fprintln(fp, "// No description provided by Valve")
 					} else {
-						switch p.varType {
-						case "string":
-							typeString = "string"
-							break
-						case "{message}":
-							typeString = "string"
-							break
-						case "int32":
-							typeString = "int32"
-							break
-						case "bool":
-							typeString = "bool"
-							break
-						case "float":
-							typeString = "float32"
-							break
-						case "rawbinary":
-							typeString = "[]byte"
-							break
-						case "uint32":
-							typeString = "uint32"
-							break
-						case "uint64":
-							typeString = "uint64"
-							break
-						}
-
-						name := toPrettyGoName(p.name)
-
-						if p.optional {
-							optParams[p.name] = &Param{
-								name:      name,
-								paramType: typeString,
-								required:  !p.optional,
-							}
-						} else {
-							reqParams[p.name] = &Param{
-								name:      name,
-								paramType: typeString,
-								required:  !p.optional,
-							}
-						}
-
-						if p.description == "" {
-							fmt.Fprintln(fp, "// No description provided by Valve")
-						} else {
-							fmt.Fprintf(fp, "// %s\n", p.description)
-						}
-
-						fmt.Fprintf(fp, "%s %s\n", name, typeString)
+						fmt.Fprintf(fp, "// %s\n", v.description)
 					}
+					fmt.Fprintf(fp, "%s %s `%s`\n", v.name, v.paramType, v.tag(useJSON))
 				}
 				fmt.Fprintf(fp, "}\n\n")
 
+				writeConstructor(fp, tmplMethodName, params)
+
 				tmplData["requiresKey"] = requiresKey
 
 				// Func
@@ -260,60 +259,13 @@ func main() {
 					return
 				}
 
-				// This is where I do a walkthrough of the variables, building a query based on type.
-				// Required = easy
-				for n, v := range reqParams {
-					// This is sort of nasty.
-					switch v.paramType {
-					case "string":
-						fmt.Fprintf(fp, "params.AddString(\"%s\", method.%s)\n", n, v.name)
-						break
-					case "int32":
-						fmt.Fprintf(fp, "params.AddInt32(\"%s\", method.%s)\n", n, v.name)
-						break
-					case "bool":
-						fmt.Fprintf(fp, "params.AddBoolean(\"%s\",  method.%s)\n", n, v.name)
-						break
-					case "float32":
-						fmt.Fprintf(fp, "params.AddFloat32(\"%s\", method.%s)\n", n, v.name)
-						break
-					case "[]byte":
-						fmt.Fprintf(fp, "params.AddBytes(\"%s\", method.%s)\n", n, v.name)
-						break
-					case "uint32":
-						fmt.Fprintf(fp, "params.AddUInt32(\"%s\", method.%s)\n", n, v.name)
-						break
-					case "uint64":
-						fmt.Fprintf(fp, "params.AddUInt64(\"%s\", method.%s)\n", n, v.name)
-						break
-					}
-				}
+				fmt.Fprintf(fp, "if err := method.Validate(); err != nil {\nreturn nil, err\n}\n")
 
-				for n, v := range optParams {
-					// This is sort of nasty.
-					switch v.paramType {
-					case "string":
-						fmt.Fprintf(fp, "if method.%s != \"\" {\nparams.AddString(\"%s\", method.%s)\n}\n", v.name, n, v.name)
-						break
-					case "int32":
-						fmt.Fprintf(fp, "if method.%s != 0 {\nparams.AddInt32(\"%s\", method.%s)\n}\n", v.name, n, v.name)
-						break
-					case "bool":
-						fmt.Fprintf(fp, "if method.%s != false {\nparams.AddBoolean(\"%s\",  method.%s)\n}\n", v.name, n, v.name)
-						break
-					case "float32":
-						fmt.Fprintf(fp, "if method.%s != 0 {\nparams.AddFloat32(\"%s\", method.%s)\n}\n", v.name, n, v.name)
-						break
-					case "[]byte":
-						fmt.Fprintf(fp, "if method.%s != nil {\nparams.AddBytes(\"%s\", method.%s)\n}\n", v.name, n, v.name)
-						break
-					case "uint32":
-						fmt.Fprintf(fp, "if method.%s != 0 {\nparams.AddUInt32(\"%s\", method.%s)\n}\n", v.name, n, v.name)
-						break
-					case "uint64":
-						fmt.Fprintf(fp, "if method.%s != 0 {\nparams.AddUInt64(\"%s\", method.%s)\n}\n", v.name, n, v.name)
-						break
-					}
+				// The parameters are encoded from the struct tags
+				if useJSON {
+					fmt.Fprintf(fp, "params := core.NewParameters()\nif err := params.SetInputJSON(method); err != nil {\nreturn nil, err\n}\n")
+				} else {
+					fmt.Fprintf(fp, "params, err := core.EncodeParameters(method)\nif err != nil {\nreturn nil, err\n}\n")
 				}
 
 				// This does absolutely nothing special, in reality.
@@ -338,6 +290,14 @@ func main() {
 
 				// End of function -- safety first, pad it with newlines
 				fmt.Fprintf(fp, "\n}\n")
+
+				if hasProto {
+					err = tmplFuncProto.Execute(fp, tmplData)
+					if err != nil {
+						fmt.Printf("failed to execute template (funcProto)\nerr: %s\n\n", err)
+						return
+					}
+				}
 			}
 
 			//
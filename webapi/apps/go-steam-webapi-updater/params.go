@@ -0,0 +1,258 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type Param struct {
+	name        string
+	paramType   string
+	required    bool
+	description string
+
+	// Name sent to the WebAPI
+	wireName string
+
+	// "array" (name[0], name[1], ...), "list" (comma-delimited) or ""
+	list string
+
+	// Whether the parameter is an ID, which is never zero
+	nonzero bool
+
+	// The array this parameter holds the length of (if any)
+	countOf *Param
+
+	// The parameter holding this array's length (if any)
+	count *Param
+}
+
+// Go types for the WebAPI's parameter types
+var goTypes = map[string]string{
+	"string":    "string",
+	"{message}": "string",
+	"int32":     "int32",
+	"int64":     "int64",
+	"bool":      "bool",
+	"float":     "float32",
+	"rawbinary": "[]byte",
+	"uint32":    "uint32",
+	"fixed32":   "uint32",
+	"uint64":    "uint64",
+	"fixed64":   "uint64",
+	"{enum}":    "int32",
+}
+
+// The Go type of 64-bit parameters named for SteamIDs
+const steamIDType = "steamid.SteamID"
+
+// Endings of the names of integer parameters holding IDs, for which zero
+// is never a real value (instanceid and contextid are left out, as zero
+// is a real instance or context)
+var idSuffixes = []string{
+	"steamid",
+	"appid",
+	"publishedfileid",
+	"groupid",
+	"clanid",
+	"itemid",
+	"ugcid",
+	"accountid",
+}
+
+// isIDParam reports whether an integer parameter holds an ID.
+func isIDParam(name string) bool {
+	name = strings.TrimSuffix(strings.ToLower(name), "[0]")
+	name = strings.TrimSuffix(name, "s")
+	for _, suffix := range idSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildParams converts the parameters of a method (sorted, so the
+// output is stable), noting whether the key is required.
+func buildParams(method *apiSteamVersionedMethod, useJSON bool) (params []*Param, requiresKey bool) {
+	names := make([]string, 0, len(method.params))
+	for n := range method.params {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var arrays []*Param
+	for _, n := range names {
+		p := method.params[n]
+		if p.name == "key" {
+			requiresKey = true
+			continue
+		}
+
+		typeString, ok := goTypes[p.varType]
+		if !ok {
+			typeString = "string"
+		}
+		if useJSON && p.varType == "{message}" {
+			typeString = "interface{}"
+		}
+		if typeString == "uint64" && strings.Contains(strings.ToLower(p.name), "steamid") {
+			typeString = steamIDType
+		}
+
+		param := &Param{
+			paramType:   typeString,
+			required:    !p.optional,
+			description: p.description,
+			wireName:    p.name,
+		}
+		switch typeString {
+		case steamIDType:
+			param.nonzero = true
+		case "int32", "int64", "uint32", "uint64":
+			param.nonzero = isIDParam(p.name)
+		}
+
+		// Lists are either indexed (name[0], name[1], ...)
+		// or comma-delimited (noted in the description).
+		if strings.HasSuffix(p.name, "[0]") {
+			param.wireName = strings.TrimSuffix(p.name, "[0]")
+			param.paramType = "[]" + typeString
+			param.list = "array"
+			arrays = append(arrays, param)
+		} else if !useJSON && typeString == "string" && strings.Contains(strings.ToLower(p.description), "comma") {
+			param.paramType = "[]string"
+			if strings.Contains(strings.ToLower(p.name), "steamid") {
+				param.paramType = "[]" + steamIDType
+				param.nonzero = true
+			}
+			param.list = "list"
+		}
+
+		// Optional values are pointers, so an explicit zero (e.g.
+		// include_appinfo=false) can still be sent; nil is left out.
+		if !param.required && param.list == "" && typeString != "interface{}" && typeString != "[]byte" {
+			param.paramType = "*" + typeString
+		}
+
+		param.name = toFieldName(param.wireName)
+		params = append(params, param)
+	}
+
+	// Methods taking a single indexed array usually take its length
+	// too (e.g. itemcount with publishedfileids); fill that in.
+	if len(arrays) == 1 && !useJSON {
+		for _, v := range params {
+			if v.required && v.wireName != "count" && strings.HasSuffix(v.wireName, "count") && v.paramType == "uint32" {
+				v.countOf = arrays[0]
+				arrays[0].count = v
+				break
+			}
+		}
+	}
+
+	return params, requiresKey
+}
+
+// usesSteamID reports whether any of params is a SteamID (so the
+// steamid package must be imported).
+func usesSteamID(params []*Param) bool {
+	for _, v := range params {
+		if strings.Contains(v.paramType, steamIDType) {
+			return true
+		}
+	}
+	return false
+}
+
+// tag returns the struct tag for the parameter.
+func (p *Param) tag(useJSON bool) string {
+	if useJSON {
+		options := ""
+		if !p.required {
+			options += ",omitempty"
+		}
+		if p.nonzero {
+			options += ",nonzero"
+		}
+		return fmt.Sprintf(`json:"%s%s"`, p.wireName, options)
+	}
+
+	options := ""
+	if !p.required {
+		options += ",optional"
+	}
+	if p.list == "list" {
+		options += ",list"
+	}
+	if p.count != nil {
+		options += ",count=" + p.count.wireName
+	}
+	if p.nonzero {
+		options += ",nonzero"
+	}
+	return fmt.Sprintf(`steam:"%s%s"`, p.wireName, options)
+}
+
+// Packages imported by generated files, which arguments must not shadow
+var importedNames = map[string]bool{
+	"context": true,
+	"core":    true,
+	"steamid": true,
+	"pb":      true,
+}
+
+// argName returns the constructor argument name used for a parameter.
+func (p *Param) argName() string {
+	r := []rune(p.name)
+	r[0] = unicode.ToLower(r[0])
+	out := string(r)
+	if token.IsKeyword(out) || out == "method" || importedNames[out] {
+		out += "Param"
+	}
+	return out
+}
+
+// writeConstructor writes New<method>, taking the required parameters,
+// and a Set method for each optional one.
+func writeConstructor(w io.Writer, method string, params []*Param) {
+	var args, fields []string
+	for _, v := range params {
+		if v.countOf != nil || !v.required {
+			continue
+		}
+		args = append(args, fmt.Sprintf("%s %s", v.argName(), v.paramType))
+		fields = append(fields, fmt.Sprintf("%s: %s,\n", v.name, v.argName()))
+	}
+
+	fmt.Fprintf(w, "// New%s creates a %s from its required parameters;\n", method, method)
+	fmt.Fprintf(w, "// optional ones are set with its Set methods.\n")
+	fmt.Fprintf(w, "func New%s(%s) *%s {\n", method, strings.Join(args, ", "), method)
+	if len(fields) == 0 {
+		fmt.Fprintf(w, "return &%s{}\n}\n\n", method)
+	} else {
+		fmt.Fprintf(w, "return &%s{\n%s}\n}\n\n", method, strings.Join(fields, ""))
+	}
+
+	for _, v := range params {
+		if v.required {
+			continue
+		}
+
+		value, valueType := "value", v.paramType
+		if strings.HasPrefix(valueType, "*") {
+			value, valueType = "&value", valueType[1:]
+		}
+		fmt.Fprintf(w, "// Set%s sets the optional %s parameter.\n", v.name, v.wireName)
+		fmt.Fprintf(w, "func (method *%s) Set%s(value %s) *%s {\n", method, v.name, valueType, method)
+		fmt.Fprintf(w, "method.%s = %s\nreturn method\n}\n\n", v.name, value)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package main
+
+import "testing"
+
+func TestIsIDParam(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"steamid", true},
+		{"steamids", true},
+		{"target_steamid", true},
+		{"appid", true},
+		{"appids_filter", false},
+		{"publishedfileids[0]", true},
+		{"groupid", true},
+		{"clanid", true},
+		{"itemid", true},
+		{"accountid", true},
+		{"instanceid", false},
+		{"contextid", false},
+		{"count", false},
+		{"language", false},
+	}
+
+	for _, test := range tests {
+		if got := isIDParam(test.name); got != test.want {
+			t.Errorf("isIDParam(%q) = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestParamTag(t *testing.T) {
+	tests := []struct {
+		param   Param
+		useJSON bool
+		want    string
+	}{
+		{Param{wireName: "steamid", required: true, nonzero: true}, false, `steam:"steamid,nonzero"`},
+		{Param{wireName: "appid", nonzero: true}, false, `steam:"appid,optional,nonzero"`},
+		{Param{wireName: "steamids", required: true, list: "list", nonzero: true}, false, `steam:"steamids,list,nonzero"`},
+		{Param{wireName: "count", required: true}, false, `steam:"count"`},
+		{Param{wireName: "steamid", required: true, nonzero: true}, true, `json:"steamid,nonzero"`},
+		{Param{wireName: "appid", nonzero: true}, true, `json:"appid,omitempty,nonzero"`},
+	}
+
+	for _, test := range tests {
+		if got := test.param.tag(test.useJSON); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.param.wireName, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// A protoRPC names the messages taken and returned by a service method.
+type protoRPC struct {
+	request  string
+	response string
+}
+
+var (
+	protoComment = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	protoPackage = regexp.MustCompile(`package\s+([\w.]+)\s*;`)
+	protoService = regexp.MustCompile(`service\s+(\w+)\s*\{`)
+	protoMethod  = regexp.MustCompile(`rpc\s+(\w+)\s*\(\s*\.?([\w.]+)\s*\)\s*returns\s*\(\s*\.?([\w.]+)\s*\)`)
+)
+
+// loadProtos scans the .proto files in dir for service definitions,
+// returning the RPCs keyed by WebAPI interface (service Player being
+// IPlayerService) and then method name.
+//
+// This is a scan rather than a parse; it only needs service blocks.
+func loadProtos(dir string) (map[string]map[string]protoRPC, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.proto"))
+	if err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]map[string]protoRPC)
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		src := protoComment.ReplaceAllString(string(raw), "")
+
+		pkg := ""
+		if m := protoPackage.FindStringSubmatch(src); m != nil {
+			pkg = m[1]
+		}
+
+		for _, loc := range protoService.FindAllStringSubmatchIndex(src, -1) {
+			name := src[loc[2]:loc[3]]
+			body := protoBlock(src[loc[1]:])
+
+			methods := make(map[string]protoRPC)
+			for _, m := range protoMethod.FindAllStringSubmatch(body, -1) {
+				methods[m[1]] = protoRPC{
+					request:  protoGoName(m[2], pkg),
+					response: protoGoName(m[3], pkg),
+				}
+			}
+			services["I"+name+"Service"] = methods
+		}
+	}
+	return services, nil
+}
+
+// protoBlock returns src up to the brace closing the block it starts inside.
+func protoBlock(src string) string {
+	depth := 1
+	for i, c := range src {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return src[:i]
+			}
+		}
+	}
+	return src
+}
+
+// protoGoName returns the Go type protoc-gen-go generates for a message
+// named in a file of package pkg. The package (pkg, or any other, taken to
+// be the leading lower case parts) is dropped, and nested messages are
+// joined with underscores: CMsg.Inner is CMsg_Inner.
+func protoGoName(message string, pkg string) string {
+	message = strings.TrimPrefix(message, ".")
+	if pkg != "" {
+		message = strings.TrimPrefix(message, pkg+".")
+	}
+
+	parts := strings.Split(message, ".")
+	for len(parts) > 1 && parts[0] != "" && unicode.IsLower(rune(parts[0][0])) {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, "_")
+}
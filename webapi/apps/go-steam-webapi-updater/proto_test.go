@@ -0,0 +1,73 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProtoGoName(t *testing.T) {
+	tests := []struct {
+		message string
+		pkg     string
+		want    string
+	}{
+		{"CPlayer_GetOwnedGames_Request", "", "CPlayer_GetOwnedGames_Request"},
+		{".CPlayer_GetOwnedGames_Request", "", "CPlayer_GetOwnedGames_Request"},
+		{"CMsg.Inner", "", "CMsg_Inner"},
+		{".CMsg.Inner.Deeper", "", "CMsg_Inner_Deeper"},
+		{"steam.CMsg", "steam", "CMsg"},
+		{".steam.CMsg.Inner", "steam", "CMsg_Inner"},
+		{"valve.steam.CMsg.Inner", "valve.steam", "CMsg_Inner"},
+		{"other.CMsg.Inner", "steam", "CMsg_Inner"},
+	}
+
+	for _, test := range tests {
+		if got := protoGoName(test.message, test.pkg); got != test.want {
+			t.Errorf("protoGoName(%q, %q) = %s, want %s", test.message, test.pkg, got, test.want)
+		}
+	}
+}
+
+func TestLoadProtos(t *testing.T) {
+	dir := t.TempDir()
+	src := `syntax = "proto2";
+package steam;
+
+message CPlayer_GetOwnedGames_Request {
+	optional uint64 steamid = 1;
+	message Filter { optional bool played = 1; }
+}
+
+// service Commented { rpc Ignored (.A) returns (.B); }
+service Player {
+	option (service_description) = "Player methods";
+	rpc GetOwnedGames (.steam.CPlayer_GetOwnedGames_Request) returns (.CPlayer_GetOwnedGames_Response) {
+		option (method_description) = "{ braces }";
+	}
+	rpc GetFiltered (CPlayer_GetOwnedGames_Request.Filter) returns (.steam.CPlayer_GetOwnedGames_Response.Game);
+}
+`
+	if err := ioutil.WriteFile(filepath.Join(dir, "player.proto"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadProtos(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]protoRPC{
+		"IPlayerService": {
+			"GetOwnedGames": {request: "CPlayer_GetOwnedGames_Request", response: "CPlayer_GetOwnedGames_Response"},
+			"GetFiltered":   {request: "CPlayer_GetOwnedGames_Request_Filter", response: "CPlayer_GetOwnedGames_Response_Game"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
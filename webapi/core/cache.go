@@ -0,0 +1,79 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// A Cache stores response bodies.
+//
+// Implementations must be safe for concurrent use; failures should
+// simply be treated as misses.
+type Cache interface {
+	// Get returns the content stored under key, if it has not expired.
+	Get(key string) (content []byte, ok bool)
+
+	// Set stores content under key for ttl.
+	Set(key string, content []byte, ttl time.Duration)
+}
+
+// CachePolicy decides how long successful GET responses are cached.
+type CachePolicy struct {
+	// TTL used for anything not listed in TTL (zero disables caching)
+	Default time.Duration
+
+	// TTLs keyed by "Interface" or "Interface/Method" (e.g. "ISteamApps"
+	// or "ISteamUser/GetPlayerSummaries"); the most specific match wins
+	// and a zero value disables caching.
+	TTL map[string]time.Duration
+}
+
+// WithCache caches GET responses according to policy.
+func WithCache(cache Cache, policy CachePolicy) Option {
+	return func(conn *Connection) {
+		conn.cache = cache
+		conn.cachePolicy = policy
+	}
+}
+
+// ttl returns the TTL for a method URI (e.g. "ISteamUser/ResolveVanityURL/v1/").
+func (policy *CachePolicy) ttl(uri string) time.Duration {
+	parts := strings.SplitN(uri, "/", 3)
+	if len(parts) >= 2 {
+		if ttl, ok := policy.TTL[parts[0]+"/"+parts[1]]; ok {
+			return ttl
+		}
+	}
+	if ttl, ok := policy.TTL[parts[0]]; ok {
+		return ttl
+	}
+	return policy.Default
+}
+
+// cacheEntry returns the cache key and TTL for a GET of uri; a zero
+// TTL means the response must not be cached.
+//
+// The key is built from the method URI and the sorted parameters, with
+// the API key removed.
+func (conn *Connection) cacheEntry(uri string, params url.Values) (key string, ttl time.Duration) {
+	if conn.cache == nil {
+		return "", 0
+	}
+	ttl = conn.cachePolicy.ttl(uri)
+	if ttl <= 0 {
+		return "", 0
+	}
+
+	values := url.Values{}
+	for name, value := range params {
+		if name != "key" {
+			values[name] = value
+		}
+	}
+	return conn.baseURI + uri + "?" + values.Encode(), ttl
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DiskCache is a Cache storing one file per entry in a directory.
+//
+// Each file holds the expiry time followed by the content; expired files
+// are removed when they are next read. Entry files end in ".cache" (and
+// files being written in ".cache-tmp"); nothing else in the directory is
+// touched, so it may be shared.
+type DiskCache struct {
+	dir string
+}
+
+// The extensions of entry files and of entries being written.
+const (
+	diskCacheExt     = ".cache"
+	diskCacheTempExt = ".cache-tmp"
+)
+
+// NewDiskCache creates a cache in dir, creating the directory if required.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path returns the file used for key.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+diskCacheExt)
+}
+
+// Get implements Cache.
+func (c *DiskCache) Get(key string) (content []byte, ok bool) {
+	path := c.path(key)
+	raw, err := ioutil.ReadFile(path)
+	if err != nil || len(raw) < 8 {
+		return nil, false
+	}
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(raw[:8])))
+	if time.Now().After(expires) {
+		os.Remove(path)
+		return nil, false
+	}
+	return raw[8:], true
+}
+
+// Set implements Cache.
+func (c *DiskCache) Set(key string, content []byte, ttl time.Duration) {
+	raw := make([]byte, 8+len(content))
+	binary.BigEndian.PutUint64(raw[:8], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(raw[8:], content)
+
+	// Write then rename, so readers never see a partial entry
+	fp, err := ioutil.TempFile(c.dir, "*"+diskCacheTempExt)
+	if err != nil {
+		return
+	}
+	_, err = fp.Write(raw)
+	fp.Close()
+	if err != nil {
+		os.Remove(fp.Name())
+		return
+	}
+	if os.Rename(fp.Name(), c.path(key)) != nil {
+		os.Remove(fp.Name())
+	}
+}
+
+// Clear removes every entry (and any left half-written), leaving other
+// files in the directory alone.
+func (c *DiskCache) Clear() error {
+	entries, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Mode().IsRegular() || !(strings.HasSuffix(name, diskCacheExt) || strings.HasSuffix(name, diskCacheTempExt)) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
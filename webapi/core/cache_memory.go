@@ -0,0 +1,91 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-memory LRU Cache. Content is copied in and out,
+// so callers may modify what they store or get back.
+type MemoryCache struct {
+	mu sync.Mutex
+
+	// Maximum number of entries
+	capacity int
+
+	// Most recently used first
+	order *list.List
+
+	// Entries by key
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key     string
+	content []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates an LRU cache holding up to capacity responses.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) (content []byte, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return append([]byte(nil), entry.content...), true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, content []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &memoryEntry{
+		key:     key,
+		content: append([]byte(nil), content...),
+		expires: time.Now().Add(ttl),
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Len returns the number of stored entries (expired entries included).
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
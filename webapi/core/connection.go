@@ -15,6 +15,9 @@ type Connection struct {
 	// API Key
 	key string
 
+	// If true the key is sent as a header rather than a parameter
+	keyInHeader bool
+
 	// If true, the partner API is used (and secure is forced)
 	partner bool
 
@@ -26,6 +29,32 @@ type Connection struct {
 
 	// A buffered baseURI
 	baseURI string
+
+	// Headers added to every request
+	header http.Header
+
+	// Retry policy (nil disables retries)
+	retry *RetryPolicy
+
+	// Request limiter (may be nil)
+	limiter Limiter
+
+	// Response cache (may be nil) and the TTLs used with it
+	cache       Cache
+	cachePolicy CachePolicy
+
+	// Response format requested (empty for the WebAPI default)
+	format Format
+
+	// Codec used by CallProtobuf (nil for the default)
+	protoCodec ProtoCodec
+
+	// Largest response body accepted (zero for no limit)
+	maxResponseSize int64
+
+	// Middleware, outermost first, and the resulting chain
+	middleware []Middleware
+	handler    Handler
 }
 
 // IsPartner returns true if the connection is a partner connection.
@@ -38,6 +67,11 @@ func (conn *Connection) IsSecure() bool {
 	return conn.secure
 }
 
+// BaseURI returns the URI requests are made against.
+func (conn *Connection) BaseURI() string {
+	return conn.baseURI
+}
+
 // HasKey returns true if an API key is stored.
 // Validity is not ensured.
 func (conn *Connection) HasKey() bool {
@@ -52,31 +86,53 @@ func (conn *Connection) HasKey() bool {
 // The fetch parameter specifies whether or not the API should be fetched, if not the user is
 // expected to load a copy.
 func NewConnection(key string, useSecureProtocol bool, partner bool) (conn *Connection) {
+	return NewConnectionWithOptions(key,
+		WithSecure(useSecureProtocol),
+		WithPartner(partner),
+	)
+}
+
+// NewConnectionWithOptions creates a new connection to the Steam API configured by options.
+//
+// Without options the connection uses the public endpoint over HTTPS with
+// a 5 second timeout.
+func NewConnectionWithOptions(key string, options ...Option) (conn *Connection) {
 
 	// First, assume it's valid
 	conn = &Connection{
-		key:     key,
-		partner: partner,
-		secure:  useSecureProtocol,
+		key:    key,
+		secure: true,
 		client: &http.Client{
 			CheckRedirect: nil,             // not required at present
 			Jar:           nil,             // not required at present
 			Timeout:       5 * time.Second, // 5 second timeout
 		},
+		header: http.Header{},
 	}
 
-	// Build the baseURI
-	base := publicAPI
-	if partner {
-		// Partner queries must be secure
+	for _, option := range options {
+		option(conn)
+	}
+
+	// Partner queries must be secure
+	if conn.partner {
 		conn.secure = true
-		base = partnerAPI
 	}
-	proto := "http://"
-	if conn.secure {
-		proto = "https://"
+
+	// Build the baseURI (unless one was given)
+	if conn.baseURI == "" {
+		base := publicAPI
+		if conn.partner {
+			base = partnerAPI
+		}
+		proto := "http://"
+		if conn.secure {
+			proto = "https://"
+		}
+		conn.baseURI = fmt.Sprintf("%s%s/", proto, base)
 	}
-	conn.baseURI = fmt.Sprintf("%s%s/", proto, base)
+
+	conn.handler = conn.chain()
 
 	return conn
 }
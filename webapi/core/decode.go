@@ -0,0 +1,178 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/awstanley/GoSteam/steam"
+)
+
+// ErrResult is matched (via errors.Is) by every *ResultError.
+var ErrResult = errors.New("request was unsuccessful")
+
+// ResultError is returned when a response reports failure, either in
+// its body (e.g. {"response": {"success": 42, "message": "No match"}}) or
+// through the X-eresult header of a 2xx response.
+type ResultError struct {
+	// The result reported (EResultFail for "success": false)
+	Code steam.EResult
+
+	// The message or error reported, if any
+	Message string
+}
+
+// Error implements error.
+func (e *ResultError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("request failed with result %s (%d)", e.Code.String(), int(e.Code))
+	}
+	return fmt.Sprintf("request failed with result %s (%d): %s", e.Code.String(), int(e.Code), e.Message)
+}
+
+// Is reports whether target is ErrResult.
+func (e *ResultError) Is(target error) bool {
+	return target == ErrResult
+}
+
+// Unwrap returns the result, so errors.Is(err, steam.EResultNoMatch) works.
+func (e *ResultError) Unwrap() error {
+	return e.Code
+}
+
+// The objects the WebAPI wraps responses in.
+var envelopes = map[string]bool{
+	"response":    true,
+	"result":      true,
+	"playerstats": true,
+	"applist":     true,
+	"friendslist": true,
+}
+
+// Decode decodes a JSON response into a new T; see DecodeResponse.
+func Decode[T any](contents []byte) (T, error) {
+	return DecodeFormat[T](FormatJSON, contents)
+}
+
+// DecodeFormat decodes a response in the given format into a new T;
+// see DecodeResponse.
+func DecodeFormat[T any](format Format, contents []byte) (T, error) {
+	var v T
+	err := DecodeResponse(format, contents, &v)
+	return v, err
+}
+
+// CallAndDecode sends request and decodes the response (in the format it
+// asked for) into a new T; see Connection.DecodeResponse.
+func CallAndDecode[T any](ctx context.Context, conn *Connection, request *Request) (T, error) {
+	var v T
+	contents, err := conn.Do(ctx, request)
+	if err != nil {
+		return v, err
+	}
+	err = conn.DecodeResponse(request.Format(), contents, &v)
+	return v, err
+}
+
+// DecodeResponse decodes a response in the given format into v, as
+// Unmarshal does, after removing the envelope the WebAPI wraps most
+// responses in ("response", "result", "playerstats", "applist" or
+// "friendslist"), so v describes only what is inside it.
+//
+// Bodies reporting failure, through a "success" other than 1 or true
+// (with an optional "message" or "error"), return a *ResultError instead,
+// which matches the reported steam.EResult.
+func DecodeResponse(format Format, contents []byte, v interface{}) error {
+	switch format {
+	case FormatJSON, "":
+		var outer map[string]json.RawMessage
+		if json.Unmarshal(contents, &outer) == nil && len(outer) == 1 {
+			for name, inner := range outer {
+				if envelopes[name] {
+					contents = inner
+				}
+			}
+		}
+
+		var status struct {
+			Success interface{} `json:"success"`
+			Message string      `json:"message"`
+			Error   string      `json:"error"`
+		}
+		if json.Unmarshal(contents, &status) == nil {
+			if err := checkSuccess(fmt.Sprint(status.Success), status.Success != nil, status.Message+status.Error); err != nil {
+				return err
+			}
+		}
+		return json.Unmarshal(contents, v)
+
+	case FormatXML, FormatVDF:
+		var root *node
+		var err error
+		if format == FormatXML {
+			root, err = parseXML(contents)
+		} else {
+			root, err = parseVDF(contents)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(root.children) == 1 && envelopes[strings.ToLower(root.children[0].name)] {
+			root = root.children[0]
+		}
+
+		var success, message string
+		var found bool
+		for _, child := range root.children {
+			if !child.leaf {
+				continue
+			}
+			switch strings.ToLower(child.name) {
+			case "success":
+				success, found = child.value, true
+			case "message", "error":
+				message = child.value
+			}
+		}
+		if err := checkSuccess(success, found, message); err != nil {
+			return err
+		}
+		return decodeTree(root, v)
+	}
+	return Unmarshal(format, contents, v)
+}
+
+// DecodeResponse is DecodeResponse, decoding protobuf responses (which
+// have no envelope) with the connection's ProtoCodec.
+func (conn *Connection) DecodeResponse(format Format, contents []byte, v interface{}) error {
+	if format == FormatProtobuf {
+		return conn.ProtoCodec().Unmarshal(contents, v)
+	}
+	return DecodeResponse(format, contents, v)
+}
+
+// checkSuccess returns a *ResultError if success reports failure.
+func checkSuccess(success string, found bool, message string) error {
+	if !found {
+		return nil
+	}
+	switch strings.ToLower(success) {
+	case "1", "true":
+		return nil
+	case "false", "0":
+		return &ResultError{Code: steam.EResultFail, Message: message}
+	}
+	code, err := strconv.Atoi(success)
+	if err != nil {
+		return fmt.Errorf("unexpected success value '%s'", success)
+	}
+	return &ResultError{Code: steam.EResult(code), Message: message}
+}
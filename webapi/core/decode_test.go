@@ -0,0 +1,149 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/awstanley/GoSteam/steam"
+)
+
+// game is the struct every testdata/game.* fixture decodes into.
+type game struct {
+	AppID        int           `json:"appid"`
+	Name         string        `json:"name"`
+	Description  string        `json:"description"`
+	Tags         []string      `json:"tags"`
+	Achievements []achievement `json:"achievements"`
+	Empty        []string      `json:"empty"`
+}
+
+type achievement struct {
+	Name     string `json:"name"`
+	Achieved int    `json:"achieved"`
+	Stats    []int  `json:"stats"`
+}
+
+var wantGame = game{
+	AppID:       220,
+	Name:        "Half-Life 2: \"Episode\" \\ Two\n(beta)",
+	Description: "",
+	Tags:        []string{"FPS", "Sci-fi"},
+	Achievements: []achievement{
+		{Name: "HL2_HIT_CANCOP_WITHCAN", Achieved: 1, Stats: []int{10, 20}},
+		{Name: "HL2_PUT_CANINTRASH", Achieved: 0},
+	},
+}
+
+// normalise clears empty slices, which the formats decode as either nil
+// or empty.
+func (g game) normalise() game {
+	if len(g.Empty) == 0 {
+		g.Empty = nil
+	}
+	achievements := make([]achievement, len(g.Achievements))
+	for i, a := range g.Achievements {
+		if len(a.Stats) == 0 {
+			a.Stats = nil
+		}
+		achievements[i] = a
+	}
+	g.Achievements = achievements
+	return g
+}
+
+var formats = []struct {
+	format Format
+	ext    string
+}{
+	{FormatJSON, "json"},
+	{FormatXML, "xml"},
+	{FormatVDF, "vdf"},
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	for _, f := range formats {
+		t.Run(f.ext, func(t *testing.T) {
+			data := readFixture(t, "game."+f.ext)
+
+			var wrapped struct {
+				Response game `json:"response"`
+			}
+			if err := Unmarshal(f.format, data, &wrapped); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if got := wrapped.Response.normalise(); !reflect.DeepEqual(got, wantGame) {
+				t.Errorf("Unmarshal:\ngot  %+v\nwant %+v", got, wantGame)
+			}
+
+			var unwrapped game
+			if err := DecodeResponse(f.format, data, &unwrapped); err != nil {
+				t.Fatalf("DecodeResponse: %v", err)
+			}
+			if got := unwrapped.normalise(); !reflect.DeepEqual(got, wantGame) {
+				t.Errorf("DecodeResponse:\ngot  %+v\nwant %+v", got, wantGame)
+			}
+		})
+	}
+}
+
+func TestDecodeFailure(t *testing.T) {
+	for _, f := range formats {
+		t.Run(f.ext, func(t *testing.T) {
+			var v game
+			err := DecodeResponse(f.format, readFixture(t, "failure."+f.ext), &v)
+
+			var resultErr *ResultError
+			if !errors.As(err, &resultErr) {
+				t.Fatalf("got %v, want a *ResultError", err)
+			}
+			if resultErr.Code != steam.EResultNoMatch || resultErr.Message != "No match" {
+				t.Errorf("got %d '%s', want %d 'No match'", int(resultErr.Code), resultErr.Message, int(steam.EResultNoMatch))
+			}
+			if !errors.Is(err, ErrResult) || !errors.Is(err, steam.EResultNoMatch) {
+				t.Errorf("%v does not match ErrResult and EResultNoMatch", err)
+			}
+		})
+	}
+}
+
+func TestCheckSuccess(t *testing.T) {
+	tests := []struct {
+		body string
+		want steam.EResult
+	}{
+		{`{"response": {"success": true, "appid": 220}}`, steam.EResultOK},
+		{`{"response": {"appid": 220}}`, steam.EResultOK},
+		{`{"response": {"success": false}}`, steam.EResultFail},
+		{`{"result": {"success": 0, "error": "Failed"}}`, steam.EResultFail},
+		{`{"response": {"success": 29}}`, steam.EResultDuplicateRequest},
+	}
+
+	for _, test := range tests {
+		var v game
+		err := DecodeResponse(FormatJSON, []byte(test.body), &v)
+		if test.want == steam.EResultOK {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.body, err)
+			}
+			continue
+		}
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: got %v, want %s", test.body, err, test.want.String())
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/awstanley/GoSteam/steam"
+)
+
+// do sends a prepared request (bound to ctx) and returns the reply,
+// retrying under the connection's RetryPolicy (if any). Every attempt is
+// first admitted by the connection's Limiter (if any), then passed through
+// the middleware chain.
+//
+// If stream is set the body of a successful response is left unread in
+// Reply.Stream; otherwise it is read into Reply.Body.
+//
+// If ctx is done before the exchange completes the context's error is
+// returned in place of the transport error. Non-2xx responses are returned
+// as an *APIError, and 2xx responses whose X-eresult header reports failure
+// as a *ResultError.
+func (conn *Connection) do(ctx context.Context, request *http.Request, stream bool) (reply *Reply, err error) {
+	for name, values := range conn.header {
+		request.Header[name] = append([]string(nil), values...)
+	}
+
+	attempts := conn.retry.attempts(ctx, request.Method)
+	for attempt := 1; ; attempt++ {
+		if conn.limiter != nil {
+			if err := conn.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		call := newCall(request)
+		call.stream = stream
+		reply, err = conn.handler(ctx, call)
+		if err == nil || ctx.Err() != nil || attempt >= attempts || !conn.retry.retryable(err) {
+			if err != nil {
+				return nil, err
+			}
+			return reply, nil
+		}
+
+		if err := sleep(ctx, conn.retry.delay(attempt, err)); err != nil {
+			return nil, err
+		}
+
+		// The body has been consumed; rewind it for the next attempt
+		request = request.Clone(ctx)
+		if request.GetBody != nil {
+			if request.Body, err = request.GetBody(); err != nil {
+				return nil, conn.redactError(err)
+			}
+		}
+	}
+}
+
+// roundTrip is the innermost Handler; it performs the HTTP exchange.
+func (conn *Connection) roundTrip(ctx context.Context, call *Call) (*Reply, error) {
+	request := call.request
+	request.Header = call.Header.Clone()
+	if call.key != "" {
+		request.Header.Set(keyHeader, call.key)
+	}
+
+	start := time.Now()
+	response, err := conn.client.Do(request)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, conn.redactError(err)
+	}
+
+	reply := &Reply{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+		reply.Body, _ = ioutil.ReadAll(io.LimitReader(response.Body, apiErrorBodyLimit))
+		reply.Latency = time.Since(start)
+		if ctx.Err() != nil {
+			return reply, ctx.Err()
+		}
+		return reply, &APIError{
+			StatusCode: response.StatusCode,
+			URI:        stripKey(request.URL.String()),
+			Header:     response.Header,
+			Body:       reply.Body,
+			Result:     headerResult(response.Header),
+		}
+	}
+
+	// Service methods report failure with a 2xx status and X-eresult
+	if result := headerResult(response.Header); result != steam.EResultNone && result != steam.EResultOK {
+		response.Body.Close()
+		reply.Latency = time.Since(start)
+		return reply, &ResultError{
+			Code:    result,
+			Message: response.Header.Get("X-error_message"),
+		}
+	}
+
+	if conn.maxResponseSize > 0 && response.ContentLength > conn.maxResponseSize {
+		response.Body.Close()
+		reply.Latency = time.Since(start)
+		return reply, conn.tooLarge(request)
+	}
+
+	if call.stream {
+		reply.Stream = conn.limitBody(request, response.Body)
+		reply.Latency = time.Since(start)
+		return reply, nil
+	}
+
+	defer response.Body.Close()
+	reply.Body, err = ioutil.ReadAll(conn.limitBody(request, response.Body))
+	reply.Latency = time.Since(start)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, conn.redactError(err)
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EncodeParameters encodes the tagged fields of a struct (or pointer to
+// one) into a new parameter set. See Parameters.AddStruct.
+func EncodeParameters(v interface{}) (*Parameters, error) {
+	params := NewParameters()
+	if err := params.AddStruct(v); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// AddStruct adds the fields of a struct (or pointer to one) tagged with
+// `steam:"name"`; untagged fields and `steam:"-"` are skipped. Options
+// follow the name, separated by commas:
+//
+//	optional    zero values are not sent
+//	list        slices are comma-delimited (name=a,b) rather than indexed
+//	            (name[0]=a&name[1]=b)
+//	count=name  the slice's length is also sent as name
+//	nonzero     the value is an ID, so zero is never valid (see Validate)
+//
+// Nil pointers are never sent and non-nil pointers always are, so a
+// pointer can send an explicit zero for an optional parameter. Strings,
+// booleans, numbers (including SteamID types), time.Time (as a Unix
+// timestamp), []byte and encoding.TextMarshaler are supported, as are
+// slices of them; embedded structs are flattened.
+func (p *Parameters) AddStruct(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return fmt.Errorf("cannot encode nil %s", value.Type())
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot encode %s as parameters", value.Type())
+	}
+	return p.addStruct(value)
+}
+
+// paramTag is a parsed `steam:"..."` tag.
+type paramTag struct {
+	name     string
+	optional bool
+	list     bool
+	count    string
+	nonzero  bool
+}
+
+func parseParamTag(tag string) paramTag {
+	parts := strings.Split(tag, ",")
+	out := paramTag{name: parts[0]}
+	for _, option := range parts[1:] {
+		switch {
+		case option == "optional":
+			out.optional = true
+		case option == "list":
+			out.list = true
+		case option == "nonzero":
+			out.nonzero = true
+		case strings.HasPrefix(option, "count="):
+			out.count = strings.TrimPrefix(option, "count=")
+		}
+	}
+	return out
+}
+
+func (p *Parameters) addStruct(value reflect.Value) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		tag, tagged := field.Tag.Lookup("steam")
+
+		if field.Anonymous && !tagged {
+			embedded := value.Field(i)
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := p.addStruct(embedded); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if !tagged || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		options := parseParamTag(tag)
+		if options.name == "" {
+			options.name = field.Name
+		}
+		if err := p.addField(options, value.Field(i)); err != nil {
+			return fmt.Errorf("parameter %s: %s", options.name, err)
+		}
+	}
+	return nil
+}
+
+func (p *Parameters) addField(options paramTag, value reflect.Value) error {
+	// Pointers and interfaces are sent whenever they are set
+	explicit := false
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+		explicit = true
+	}
+
+	if options.optional && !explicit && isZero(value) {
+		return nil
+	}
+
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		return p.addSlice(options, value)
+	}
+	if value.Kind() == reflect.Array {
+		return p.addSlice(options, value)
+	}
+
+	s, err := formatParam(value)
+	if err != nil {
+		return err
+	}
+	p.Values.Add(options.name, s)
+	return nil
+}
+
+func (p *Parameters) addSlice(options paramTag, value reflect.Value) error {
+	values := make([]string, value.Len())
+	for i := range values {
+		elem := value.Index(i)
+		for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				return fmt.Errorf("nil element at index %d", i)
+			}
+			elem = elem.Elem()
+		}
+		s, err := formatParam(elem)
+		if err != nil {
+			return err
+		}
+		values[i] = s
+	}
+
+	if options.count != "" {
+		p.AddUInt32(options.count, uint32(len(values)))
+	}
+
+	if options.list {
+		if len(values) > 0 || !options.optional {
+			p.Values.Add(options.name, strings.Join(values, ","))
+		}
+		return nil
+	}
+	for i, s := range values {
+		p.Values.Add(fmt.Sprintf("%s[%d]", options.name, i), s)
+	}
+	return nil
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// formatParam formats a single value as it is sent to the WebAPI.
+func formatParam(value reflect.Value) (string, error) {
+	if value.Type() == timeType && value.CanInterface() {
+		return strconv.FormatInt(value.Interface().(time.Time).Unix(), 10), nil
+	}
+	if value.Type().Implements(textMarshalerType) && value.CanInterface() {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s", value.Type())
+}
+
+// isZero reports whether value is the zero value of its type.
+func isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	if value.Type() == timeType && value.CanInterface() {
+		return value.Interface().(time.Time).IsZero()
+	}
+	return value.IsZero()
+}
@@ -0,0 +1,135 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/awstanley/GoSteam/steam"
+)
+
+// Sentinel errors matched by an *APIError with the corresponding status
+// (use errors.Is, or the Is* helpers below).
+var (
+	ErrUnauthorized = errors.New("unauthorized (401)")
+	ErrForbidden    = errors.New("forbidden (403)")
+	ErrNotFound     = errors.New("not found (404)")
+	ErrRateLimited  = errors.New("rate limited (429)")
+	ErrServerError  = errors.New("server error (5xx)")
+	ErrUnavailable  = errors.New("service unavailable (503)")
+)
+
+// The largest part of a failed response body retained by an APIError.
+const apiErrorBodyLimit = 512
+
+// APIError is returned when the WebAPI answers with a non-2xx status.
+type APIError struct {
+	// HTTP status code
+	StatusCode int
+
+	// The request URI (with the key stripped)
+	URI string
+
+	// Response headers
+	Header http.Header
+
+	// The start of the response body (at most 512 bytes)
+	Body []byte
+
+	// The result from the X-eresult header (EResultNone if there wasn't one)
+	Result steam.EResult
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	if e.Result != steam.EResultNone {
+		return fmt.Sprintf("request to '%s' failed with status %d (%s) and result %s (%d)",
+			e.URI, e.StatusCode, http.StatusText(e.StatusCode), e.Result.String(), int(e.Result))
+	}
+	return fmt.Sprintf("request to '%s' failed with status %d (%s)",
+		e.URI, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is reports whether the error matches one of the sentinel errors, or
+// the steam.EResult reported with it.
+func (e *APIError) Is(target error) bool {
+	if result, ok := target.(steam.EResult); ok {
+		return e.Result != steam.EResultNone && e.Result == result
+	}
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500 && e.StatusCode <= 599
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	}
+	return false
+}
+
+// IsUnauthorized returns true if err is (or wraps) a 401 response.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden returns true if err is (or wraps) a 403 response.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsNotFound returns true if err is (or wraps) a 404 response.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRateLimited returns true if err is (or wraps) a 429 response.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsServerError returns true if err is (or wraps) a 5xx response.
+func IsServerError(err error) bool {
+	return errors.Is(err, ErrServerError)
+}
+
+// IsUnavailable returns true if err is (or wraps) a 503 response.
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrUnavailable)
+}
+
+// stripKey removes the key parameter from a URI.
+func stripKey(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	query := parsed.Query()
+	if _, ok := query["key"]; !ok {
+		return uri
+	}
+	query.Del("key")
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// headerResult returns the result reported in the X-eresult header
+// (EResultNone if there isn't one).
+func headerResult(header http.Header) steam.EResult {
+	code, err := strconv.Atoi(header.Get("X-eresult"))
+	if err != nil {
+		return steam.EResultNone
+	}
+	return steam.EResult(code)
+}
@@ -0,0 +1,80 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Format is a WebAPI response format (sent as the format parameter).
+type Format string
+
+// The formats the WebAPI can respond with.
+const (
+	FormatJSON Format = "json"
+	FormatXML  Format = "xml"
+	FormatVDF  Format = "vdf"
+
+	// Binary protobuf (service methods only; see CallProtobuf)
+	FormatProtobuf Format = "protobuf_raw"
+)
+
+// WithFormat requests responses in format unless a call sets its own
+// (see Parameters.SetFormat). The WebAPI defaults to JSON.
+func WithFormat(format Format) Option {
+	return func(conn *Connection) {
+		conn.format = format
+	}
+}
+
+// Format returns the format requested by the connection ("" for the WebAPI default).
+func (conn *Connection) Format() Format {
+	return conn.format
+}
+
+// SetFormat sets the response format for a single call.
+func (p *Parameters) SetFormat(format Format) {
+	p.Values.Set("format", string(format))
+}
+
+// Unmarshal decodes a response in the given format into v.
+//
+// The same struct serves every format: XML elements and VDF keys are
+// matched to fields by their json tags (or, failing that, their names,
+// ignoring case), arrays are filled from the children of an element, and
+// leaf values are converted to the field's type or passed to UnmarshalText.
+// Protobuf responses require v to have its own Unmarshal method; use
+// Connection.Unmarshal to decode them with the connection's ProtoCodec.
+func Unmarshal(format Format, data []byte, v interface{}) error {
+	switch format {
+	case FormatJSON, "":
+		return json.Unmarshal(data, v)
+	case FormatXML:
+		root, err := parseXML(data)
+		if err != nil {
+			return err
+		}
+		return decodeTree(root, v)
+	case FormatVDF:
+		root, err := parseVDF(data)
+		if err != nil {
+			return err
+		}
+		return decodeTree(root, v)
+	case FormatProtobuf:
+		return defaultProtoCodec{}.Unmarshal(data, v)
+	}
+	return fmt.Errorf("unsupported format '%s'", format)
+}
+
+// Unmarshal is Unmarshal, decoding protobuf responses with the
+// connection's ProtoCodec (as CallProtobuf does).
+func (conn *Connection) Unmarshal(format Format, data []byte, v interface{}) error {
+	if format == FormatProtobuf {
+		return conn.ProtoCodec().Unmarshal(data, v)
+	}
+	return Unmarshal(format, data, v)
+}
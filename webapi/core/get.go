@@ -5,30 +5,18 @@
 package core
 
 import (
-	"fmt"
-	"io/ioutil"
+	"context"
+	"net/http"
 )
 
 // Get performs a Get request against the base using the stored key (if required)
 func (conn *Connection) Get(uri string, params *Parameters, requireKey bool) (content []byte, err error) {
+	return conn.GetContext(context.Background(), uri, params, requireKey)
+}
 
-	if conn.partner {
-		requireKey = true
-	}
-
-	if requireKey {
-		params.SetKey(conn.key)
-	}
-	uri = fmt.Sprintf("%s%s?%s", conn.baseURI, uri, params.Encode())
-
-	response, err := conn.client.Get(uri)
-	if err != nil {
-		return nil, err
-	}
-	defer response.Body.Close()
-
-	content, err = ioutil.ReadAll(response.Body)
-
-	// Return any error that arose from the body
-	return content, err
+// GetContext performs a Get request against the base using the stored key (if required).
+//
+// params is not modified (and may be nil). See Do for the handling of ctx.
+func (conn *Connection) GetContext(ctx context.Context, uri string, params *Parameters, requireKey bool) (content []byte, err error) {
+	return conn.Do(ctx, conn.NewRequest(http.MethodGet, uri, params, requireKey))
 }
@@ -0,0 +1,353 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A Limiter admits (or refuses) requests before they are sent.
+//
+// Limits are per key, so connections sharing a key should share limiters.
+type Limiter interface {
+	// Wait returns nil once a request may be sent. It fails with a
+	// *LimitError if the limiter does not block (or waiting would never
+	// end), or ctx.Err() if ctx is done while waiting.
+	Wait(ctx context.Context) error
+}
+
+// Usage describes how much of a limiter's budget has been consumed.
+type Usage struct {
+	// Requests counted in the current window
+	Used int
+
+	// Requests permitted per window
+	Limit int
+
+	// Length of the window
+	Window time.Duration
+
+	// When the next request will be admitted (zero if one would be now,
+	// or if none ever will be)
+	Reset time.Time
+}
+
+// A Refunder is a Limiter which can give back a request it admitted but
+// which was never sent (e.g. because a later limiter refused it).
+type Refunder interface {
+	// Refund returns one admitted request to the budget
+	Refund()
+}
+
+// ErrLimitExceeded is matched (via errors.Is) by every *LimitError.
+var ErrLimitExceeded = errors.New("request limit exceeded")
+
+// LimitError is returned by a non-blocking limiter which refuses a request.
+type LimitError struct {
+	Usage
+}
+
+// Error implements error.
+func (e *LimitError) Error() string {
+	if e.Reset.IsZero() {
+		return fmt.Sprintf("request limit exceeded (%d of %d per %s)", e.Used, e.Limit, e.Window)
+	}
+	return fmt.Sprintf("request limit exceeded (%d of %d per %s); next request at %s",
+		e.Used, e.Limit, e.Window, e.Reset.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrLimitExceeded.
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// IsLimitExceeded returns true if err is (or wraps) a *LimitError.
+func IsLimitExceeded(err error) bool {
+	return errors.Is(err, ErrLimitExceeded)
+}
+
+// WithLimiter sets the limiter consulted before every attempt (retries included).
+func WithLimiter(limiter Limiter) Option {
+	return func(conn *Connection) {
+		conn.limiter = limiter
+	}
+}
+
+// CombineLimiters returns a Limiter which waits on each limiter in turn,
+// in the order given, and admits a request only once all of them have.
+//
+// Admission is all-or-nothing: if one limiter refuses (or ctx is done
+// while waiting), those which already admitted the request are refunded
+// if they implement Refunder, as RateLimiter and QuotaLimiter do. Earlier
+// limiters still hold their share while later ones block, so put blocking
+// limiters first and quotas last (a quota is then only charged once the
+// request is about to be sent).
+func CombineLimiters(limiters ...Limiter) Limiter {
+	return multiLimiter(limiters)
+}
+
+type multiLimiter []Limiter
+
+func (m multiLimiter) Wait(ctx context.Context) error {
+	for i, limiter := range m {
+		if err := limiter.Wait(ctx); err != nil {
+			m[:i].Refund()
+			return err
+		}
+	}
+	return nil
+}
+
+// Refund implements Refunder, refunding every limiter (latest first).
+func (m multiLimiter) Refund() {
+	for i := len(m) - 1; i >= 0; i-- {
+		if refunder, ok := m[i].(Refunder); ok {
+			refunder.Refund()
+		}
+	}
+}
+
+// RateLimiter is a token bucket limiting the request rate.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	// Tokens added per second
+	rate float64
+
+	// Bucket size
+	burst int
+
+	// Tokens currently available
+	tokens float64
+
+	// Last refill
+	last time.Time
+
+	// If true Wait blocks rather than failing
+	block bool
+}
+
+// NewRateLimiter creates a token bucket admitting perSecond requests per
+// second, with bursts of up to burst requests. If block is false a request
+// arriving to an empty bucket fails with a *LimitError.
+//
+// A perSecond of zero (or less) never refills the bucket, so once burst
+// requests have been made the rest fail, even if block is true.
+func NewRateLimiter(perSecond float64, burst int, block bool) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	if perSecond < 0 {
+		perSecond = 0
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+		block:  block,
+	}
+}
+
+// refill tops up the bucket; mu must be held.
+func (r *RateLimiter) refill(now time.Time) {
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > float64(r.burst) {
+		r.tokens = float64(r.burst)
+	}
+	r.last = now
+}
+
+// usage reports the bucket state; mu must be held.
+func (r *RateLimiter) usage(now time.Time) Usage {
+	usage := Usage{
+		Used:  r.burst - int(r.tokens),
+		Limit: r.burst,
+	}
+	if r.rate <= 0 {
+		// Never refilled
+		return usage
+	}
+	usage.Window = time.Duration(float64(r.burst) / r.rate * float64(time.Second))
+	if r.tokens < 1 {
+		usage.Reset = now.Add(time.Duration((1 - r.tokens) / r.rate * float64(time.Second)))
+	}
+	return usage
+}
+
+// Usage returns the current state of the bucket.
+func (r *RateLimiter) Usage() Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.refill(now)
+	return r.usage(now)
+}
+
+// Refund implements Refunder, returning a token to the bucket.
+func (r *RateLimiter) Refund() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refill(time.Now())
+	r.tokens++
+	if r.tokens > float64(r.burst) {
+		r.tokens = float64(r.burst)
+	}
+}
+
+// Wait implements Limiter.
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		now := time.Now()
+		r.refill(now)
+		if r.tokens >= 1 {
+			r.tokens--
+			r.mu.Unlock()
+			return nil
+		}
+		usage := r.usage(now)
+		r.mu.Unlock()
+
+		if !r.block || usage.Reset.IsZero() {
+			return &LimitError{usage}
+		}
+		if err := sleep(ctx, usage.Reset.Sub(now)); err != nil {
+			return err
+		}
+	}
+}
+
+// QuotaLimiter counts requests over a rolling window (typically a day).
+//
+// Requests are counted in one minute buckets, so the window rolls
+// forward a minute at a time.
+type QuotaLimiter struct {
+	mu sync.Mutex
+
+	// Requests permitted per window
+	limit int
+
+	// Length of the window
+	window time.Duration
+
+	// Per-minute counts, oldest first
+	buckets []quotaBucket
+
+	// Sum of the buckets
+	used int
+
+	// If true Wait blocks rather than failing
+	block bool
+}
+
+type quotaBucket struct {
+	start time.Time
+	count int
+}
+
+// NewQuotaLimiter creates a limiter admitting limit requests per window.
+// If block is false a request beyond the quota fails with a *LimitError.
+//
+// A limit of zero (or less) refuses every request, even if block is true;
+// a window of zero (or less) is taken as 24 hours.
+func NewQuotaLimiter(limit int, window time.Duration, block bool) *QuotaLimiter {
+	if limit < 0 {
+		limit = 0
+	}
+	if window <= 0 {
+		window = 24 * time.Hour
+	}
+	return &QuotaLimiter{
+		limit:  limit,
+		window: window,
+		block:  block,
+	}
+}
+
+// NewDailyQuota creates a QuotaLimiter with a rolling 24 hour window
+// (Valve's default quota is 100,000 calls per day).
+func NewDailyQuota(limit int, block bool) *QuotaLimiter {
+	return NewQuotaLimiter(limit, 24*time.Hour, block)
+}
+
+// expire drops buckets which have left the window; mu must be held.
+func (q *QuotaLimiter) expire(now time.Time) {
+	drop := 0
+	for drop < len(q.buckets) && now.Sub(q.buckets[drop].start) >= q.window {
+		q.used -= q.buckets[drop].count
+		drop++
+	}
+	q.buckets = q.buckets[drop:]
+}
+
+// usage reports the quota state; mu must be held.
+func (q *QuotaLimiter) usage() Usage {
+	usage := Usage{
+		Used:   q.used,
+		Limit:  q.limit,
+		Window: q.window,
+	}
+	if q.used >= q.limit && len(q.buckets) > 0 {
+		usage.Reset = q.buckets[0].start.Add(q.window)
+	}
+	return usage
+}
+
+// Usage returns the number of requests counted in the current window.
+func (q *QuotaLimiter) Usage() Usage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.expire(time.Now())
+	return q.usage()
+}
+
+// Refund implements Refunder, uncounting the most recent request (if it
+// is still within the window).
+func (q *QuotaLimiter) Refund() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.expire(time.Now())
+	if n := len(q.buckets); n > 0 {
+		q.buckets[n-1].count--
+		q.used--
+		if q.buckets[n-1].count == 0 {
+			q.buckets = q.buckets[:n-1]
+		}
+	}
+}
+
+// Wait implements Limiter.
+func (q *QuotaLimiter) Wait(ctx context.Context) error {
+	for {
+		q.mu.Lock()
+		now := time.Now()
+		q.expire(now)
+		if q.used < q.limit {
+			minute := now.Truncate(time.Minute)
+			if n := len(q.buckets); n > 0 && q.buckets[n-1].start.Equal(minute) {
+				q.buckets[n-1].count++
+			} else {
+				q.buckets = append(q.buckets, quotaBucket{start: minute, count: 1})
+			}
+			q.used++
+			q.mu.Unlock()
+			return nil
+		}
+		usage := q.usage()
+		q.mu.Unlock()
+
+		if !q.block || usage.Reset.IsZero() {
+			return &LimitError{usage}
+		}
+		if err := sleep(ctx, usage.Reset.Sub(now)); err != nil {
+			return err
+		}
+	}
+}
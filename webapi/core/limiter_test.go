@@ -0,0 +1,228 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// admit calls Wait n times without blocking, returning the number admitted.
+func admit(t *testing.T, limiter Limiter, n int) int {
+	admitted := 0
+	for i := 0; i < n; i++ {
+		err := limiter.Wait(context.Background())
+		if err == nil {
+			admitted++
+			continue
+		}
+		if !IsLimitExceeded(err) {
+			t.Fatalf("got %v, want a *LimitError", err)
+		}
+	}
+	return admitted
+}
+
+func TestRateLimiter(t *testing.T) {
+	tests := []struct {
+		name      string
+		perSecond float64
+		burst     int
+		elapsed   time.Duration
+		want      int
+	}{
+		{"empty", 1, 3, 0, 0},
+		{"burst below one", 1, 0, 0, 0},
+		{"half refilled", 10, 10, 500 * time.Millisecond, 5},
+		{"refilled", 10, 10, time.Hour, 10},
+		{"never refilled", 0, 2, time.Hour, 0},
+		{"negative rate", -5, 2, time.Hour, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRateLimiter(test.perSecond, test.burst, false)
+			if got := admit(t, r, r.burst+2); got != r.burst {
+				t.Fatalf("admitted a burst of %d, want %d", got, r.burst)
+			}
+			if got := r.Usage(); got.Used != r.burst {
+				t.Errorf("got %d used, want %d", got.Used, r.burst)
+			}
+
+			// Wind the clock back rather than sleeping
+			r.mu.Lock()
+			r.last = r.last.Add(-test.elapsed)
+			r.mu.Unlock()
+
+			if got := admit(t, r, 20); got != test.want {
+				t.Errorf("admitted %d after %s, want %d", got, test.elapsed, test.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterUsage(t *testing.T) {
+	r := NewRateLimiter(2, 4, false)
+	admit(t, r, 5)
+	usage := r.Usage()
+	if usage.Limit != 4 || usage.Window != 2*time.Second {
+		t.Errorf("got limit %d per %s, want 4 per 2s", usage.Limit, usage.Window)
+	}
+	if wait := time.Until(usage.Reset); wait <= 0 || wait > 500*time.Millisecond {
+		t.Errorf("got reset in %s, want within 500ms", wait)
+	}
+
+	never := NewRateLimiter(0, 1, false)
+	admit(t, never, 1)
+	if usage := never.Usage(); usage.Window != 0 || !usage.Reset.IsZero() {
+		t.Errorf("got window %s, reset %s; want neither", usage.Window, usage.Reset)
+	}
+}
+
+func TestQuotaLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int
+		window  time.Duration
+		elapsed time.Duration
+		want    int
+	}{
+		{"within the window", 3, time.Hour, 30 * time.Minute, 0},
+		{"window expired", 3, time.Hour, time.Hour, 3},
+		{"zero window is a day", 3, 0, 23 * time.Hour, 0},
+		{"zero window expires after a day", 3, 0, 24 * time.Hour, 3},
+		{"zero limit", 0, time.Hour, time.Hour, 0},
+		{"negative limit", -1, time.Hour, time.Hour, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewQuotaLimiter(test.limit, test.window, false)
+			limit := q.limit
+			if got := admit(t, q, limit+2); got != limit {
+				t.Fatalf("admitted %d, want %d", got, limit)
+			}
+			if got := q.Usage(); got.Used != limit || got.Limit != limit {
+				t.Errorf("got %d of %d used, want %d of %d", got.Used, got.Limit, limit, limit)
+			}
+
+			// Age the buckets rather than sleeping
+			q.mu.Lock()
+			for i := range q.buckets {
+				q.buckets[i].start = q.buckets[i].start.Add(-test.elapsed)
+			}
+			q.mu.Unlock()
+
+			if got := admit(t, q, limit+2); got != test.want {
+				t.Errorf("admitted %d after %s, want %d", got, test.elapsed, test.want)
+			}
+		})
+	}
+}
+
+func TestLimiterBlocking(t *testing.T) {
+	tests := []struct {
+		name    string
+		limiter Limiter
+		want    error
+	}{
+		{"rate limiter waits for ctx", NewRateLimiter(0.01, 1, true), context.DeadlineExceeded},
+		{"quota waits for ctx", NewQuotaLimiter(1, time.Hour, true), context.DeadlineExceeded},
+		{"empty rate limiter refuses", NewRateLimiter(0, 1, true), ErrLimitExceeded},
+		{"empty quota refuses", NewQuotaLimiter(0, time.Hour, true), ErrLimitExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The first request is admitted (if the limit allows any)
+			test.limiter.Wait(context.Background())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			err := test.limiter.Wait(ctx)
+			if !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("returned after %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestCombineLimiters(t *testing.T) {
+	t.Run("refused requests are refunded", func(t *testing.T) {
+		quota := NewQuotaLimiter(10, time.Hour, false)
+		rate := NewRateLimiter(0, 1, false)
+		if got := admit(t, CombineLimiters(quota, rate), 3); got != 1 {
+			t.Errorf("admitted %d, want 1", got)
+		}
+		if got := quota.Usage().Used; got != 1 {
+			t.Errorf("quota used %d, want 1", got)
+		}
+	})
+
+	t.Run("refused by the quota", func(t *testing.T) {
+		rate := NewRateLimiter(0, 5, false)
+		quota := NewQuotaLimiter(2, time.Hour, false)
+		if got := admit(t, CombineLimiters(rate, quota), 5); got != 2 {
+			t.Errorf("admitted %d, want 2", got)
+		}
+		if got := rate.Usage().Used; got != 2 {
+			t.Errorf("rate limiter used %d, want 2", got)
+		}
+	})
+
+	t.Run("cancelled while blocking", func(t *testing.T) {
+		quota := NewQuotaLimiter(10, time.Hour, false)
+		rate := NewRateLimiter(0.01, 1, true)
+		combined := CombineLimiters(quota, rate)
+		if err := combined.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 3; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			err := combined.Wait(ctx)
+			cancel()
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("got %v, want context.DeadlineExceeded", err)
+			}
+		}
+		if got := quota.Usage().Used; got != 1 {
+			t.Errorf("quota used %d, want 1", got)
+		}
+	})
+}
+
+func TestLimitError(t *testing.T) {
+	reset := time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		err  *LimitError
+		want string
+	}{
+		{&LimitError{Usage{Used: 10, Limit: 10, Window: time.Hour, Reset: reset}}, "request limit exceeded (10 of 10 per 1h0m0s); next request at 2016-01-02T03:04:05Z"},
+		{&LimitError{Usage{Used: 1, Limit: 1}}, "request limit exceeded (1 of 1 per 0s)"},
+	}
+
+	for _, test := range tests {
+		var err error = test.err
+		if got := err.Error(); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+		if !errors.Is(err, ErrLimitExceeded) || !IsLimitExceeded(err) {
+			t.Errorf("%v does not match ErrLimitExceeded", err)
+		}
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) || limitErr.Limit != test.err.Limit {
+			t.Errorf("errors.As failed for %v", err)
+		}
+	}
+	if IsLimitExceeded(errors.New("request limit exceeded")) {
+		t.Error("IsLimitExceeded matched an unrelated error")
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LoggingMiddleware logs every call (with the key redacted) to logger;
+// successful calls are logged at Info and failures at Warn.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*Reply, error) {
+			start := time.Now()
+			reply, err := next(ctx, call)
+
+			attrs := []slog.Attr{
+				slog.String("interface", call.Interface),
+				slog.String("method", call.Method),
+				slog.Int("version", call.Version),
+				slog.String("verb", call.Verb),
+				slog.String("params", call.Params.Encode()),
+				slog.Duration("latency", time.Since(start)),
+			}
+			if reply != nil {
+				attrs = append(attrs,
+					slog.Int("status", reply.StatusCode),
+					slog.Int("bytes", len(reply.Body)),
+				)
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelWarn, "webapi call failed", attrs...)
+			} else {
+				logger.LogAttrs(ctx, slog.LevelInfo, "webapi call", attrs...)
+			}
+			return reply, err
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A LatencyObserver receives the outcome of every call.
+type LatencyObserver interface {
+	Observe(call *Call, latency time.Duration, err error)
+}
+
+// MetricsMiddleware reports the latency of every call to observer.
+func MetricsMiddleware(observer LatencyObserver) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*Reply, error) {
+			start := time.Now()
+			reply, err := next(ctx, call)
+			observer.Observe(call, time.Since(start), err)
+			return reply, err
+		}
+	}
+}
+
+// DefaultLatencyBounds are the bucket bounds used by NewHistogram when none are given.
+var DefaultLatencyBounds = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// Histogram is a LatencyObserver which buckets latencies per "Interface/Method".
+type Histogram struct {
+	mu sync.Mutex
+
+	// Upper bounds of each bucket (ascending)
+	bounds []time.Duration
+
+	// Series by "Interface/Method"
+	series map[string]*HistogramSeries
+}
+
+// HistogramSeries holds the observations for a single method.
+type HistogramSeries struct {
+	// Upper bounds of each bucket; Counts has one extra (overflow) bucket
+	Bounds []time.Duration
+
+	// Calls per bucket
+	Counts []uint64
+
+	// Total calls and failures
+	Count  uint64
+	Errors uint64
+
+	// Sum of all latencies
+	Sum time.Duration
+}
+
+// NewHistogram creates a histogram with the given bucket bounds
+// (DefaultLatencyBounds if none are given).
+func NewHistogram(bounds ...time.Duration) *Histogram {
+	if len(bounds) == 0 {
+		bounds = DefaultLatencyBounds
+	}
+	sorted := append([]time.Duration(nil), bounds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return &Histogram{
+		bounds: sorted,
+		series: make(map[string]*HistogramSeries),
+	}
+}
+
+// Observe implements LatencyObserver.
+func (h *Histogram) Observe(call *Call, latency time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	name := call.Interface + "/" + call.Method
+	series, ok := h.series[name]
+	if !ok {
+		series = &HistogramSeries{
+			Bounds: h.bounds,
+			Counts: make([]uint64, len(h.bounds)+1),
+		}
+		h.series[name] = series
+	}
+
+	bucket := sort.Search(len(h.bounds), func(i int) bool { return latency <= h.bounds[i] })
+	series.Counts[bucket]++
+	series.Count++
+	series.Sum += latency
+	if err != nil {
+		series.Errors++
+	}
+}
+
+// Snapshot returns a copy of every series.
+func (h *Histogram) Snapshot() map[string]HistogramSeries {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	snapshot := make(map[string]HistogramSeries, len(h.series))
+	for name, series := range h.series {
+		copied := *series
+		copied.Counts = append([]uint64(nil), series.Counts...)
+		snapshot[name] = copied
+	}
+	return snapshot
+}
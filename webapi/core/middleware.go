@@ -0,0 +1,157 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The value substituted for the API key wherever it would be exposed.
+const redacted = "REDACTED"
+
+// Call describes a single WebAPI request as seen by middleware.
+type Call struct {
+	// Interface name (e.g. "ISteamUser")
+	Interface string
+
+	// Method name (e.g. "ResolveVanityURL")
+	Method string
+
+	// Method version
+	Version int
+
+	// HTTP verb
+	Verb string
+
+	// The request parameters (with the key redacted)
+	Params url.Values
+
+	// The headers to be sent; middleware may modify these
+	Header http.Header
+
+	// The underlying request (never exposed, as it holds the key)
+	request *http.Request
+
+	// The key header withheld from Header (if any)
+	key string
+
+	// If true the body of a successful response is left unread
+	stream bool
+}
+
+// Reply describes the response to a Call.
+type Reply struct {
+	// HTTP status code
+	StatusCode int
+
+	// Response headers
+	Header http.Header
+
+	// The response body (only an excerpt for non-2xx responses, and
+	// nil for streamed responses)
+	Body []byte
+
+	// The unread body of a streamed response (nil otherwise)
+	Stream io.ReadCloser
+
+	// Time taken to receive the full response (or, when streaming, the headers)
+	Latency time.Duration
+}
+
+// A Handler performs a Call.
+//
+// A non-nil Reply may accompany an error (e.g. an *APIError).
+type Handler func(ctx context.Context, call *Call) (*Reply, error)
+
+// Middleware wraps a Handler, observing or altering calls and replies.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middleware to the connection's chain; the first
+// middleware given is the outermost. Middleware runs once per attempt.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(conn *Connection) {
+		conn.middleware = append(conn.middleware, middleware...)
+	}
+}
+
+// chain builds the handler used for every attempt.
+func (conn *Connection) chain() Handler {
+	handler := Handler(conn.roundTrip)
+	for i := len(conn.middleware) - 1; i >= 0; i-- {
+		handler = conn.middleware[i](handler)
+	}
+	return handler
+}
+
+// newCall describes request for middleware.
+func newCall(request *http.Request) *Call {
+	call := &Call{
+		Verb:    request.Method,
+		Params:  request.URL.Query(),
+		Header:  request.Header.Clone(),
+		request: request,
+	}
+
+	// The key never reaches middleware
+	if key := call.Header.Get(keyHeader); key != "" {
+		call.key = key
+		call.Header.Del(keyHeader)
+	}
+
+	// The last three path segments are Interface/Method/vN
+	segments := strings.FieldsFunc(request.URL.Path, func(r rune) bool { return r == '/' })
+	if n := len(segments); n >= 3 {
+		call.Interface = segments[n-3]
+		call.Method = segments[n-2]
+		call.Version, _ = strconv.Atoi(strings.TrimPrefix(segments[n-1], "v"))
+	}
+
+	if request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			raw, _ := ioutil.ReadAll(body)
+			body.Close()
+			if form, err := url.ParseQuery(string(raw)); err == nil {
+				for name, values := range form {
+					call.Params[name] = values
+				}
+			}
+		}
+	}
+
+	if _, ok := call.Params["key"]; ok {
+		call.Params.Set("key", redacted)
+	}
+	return call
+}
+
+// HeaderMiddleware sets a header on every request.
+func HeaderMiddleware(name string, value string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*Reply, error) {
+			call.Header.Set(name, value)
+			return next(ctx, call)
+		}
+	}
+}
+
+// FaultMiddleware fails calls without sending them whenever fault
+// returns an error (for testing error handling and retries).
+func FaultMiddleware(fault func(call *Call) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call) (*Reply, error) {
+			if err := fault(call); err != nil {
+				return nil, err
+			}
+			return next(ctx, call)
+		}
+	}
+}
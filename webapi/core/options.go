@@ -0,0 +1,79 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// An Option configures a Connection built by NewConnectionWithOptions.
+//
+// Options are applied in the order given.
+type Option func(conn *Connection)
+
+// WithPartner selects the partner endpoint (which forces HTTPS).
+func WithPartner(partner bool) Option {
+	return func(conn *Connection) {
+		conn.partner = partner
+	}
+}
+
+// WithSecure selects HTTPS (true, the default) or HTTP (false).
+func WithSecure(secure bool) Option {
+	return func(conn *Connection) {
+		conn.secure = secure
+	}
+}
+
+// WithBaseURI overrides the endpoint requests are sent to (e.g. an
+// httptest server or an internal proxy). The protocol must be included.
+func WithBaseURI(uri string) Option {
+	return func(conn *Connection) {
+		if !strings.HasSuffix(uri, "/") {
+			uri += "/"
+		}
+		conn.baseURI = uri
+	}
+}
+
+// WithHTTPClient uses a copy of client in place of the default client;
+// a nil client is ignored, keeping the default.
+func WithHTTPClient(client *http.Client) Option {
+	return func(conn *Connection) {
+		if client == nil {
+			return
+		}
+		copied := *client
+		conn.client = &copied
+	}
+}
+
+// WithTransport sets the RoundTripper used by the HTTP client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(conn *Connection) {
+		conn.client.Transport = transport
+	}
+}
+
+// WithTimeout sets the HTTP client timeout (zero means no timeout).
+func WithTimeout(timeout time.Duration) Option {
+	return func(conn *Connection) {
+		conn.client.Timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(agent string) Option {
+	return WithHeader("User-Agent", agent)
+}
+
+// WithHeader sets a header sent with every request.
+func WithHeader(name string, value string) Option {
+	return func(conn *Connection) {
+		conn.header.Set(name, value)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Timeout: time.Minute}
+	tests := []struct {
+		name    string
+		client  *http.Client
+		timeout time.Duration
+	}{
+		{"nil keeps the default", nil, 5 * time.Second},
+		{"client is copied", client, time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conn := NewConnectionWithOptions("", WithBaseURI(server.URL), WithHTTPClient(test.client))
+			if conn.client == nil {
+				t.Fatal("client is nil")
+			}
+			if conn.client == client {
+				t.Error("client was not copied")
+			}
+			if conn.client.Timeout != test.timeout {
+				t.Errorf("got timeout %s, want %s", conn.client.Timeout, test.timeout)
+			}
+
+			content, err := conn.Get("ITest/Method/v1/", nil, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(content) != "ok" {
+				t.Errorf("got %q, want \"ok\"", content)
+			}
+		})
+	}
+}
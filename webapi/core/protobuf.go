@@ -0,0 +1,90 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+)
+
+// A ProtoCodec encodes and decodes protobuf messages.
+//
+// For google.golang.org/protobuf this is a two line wrapper around
+// proto.Marshal and proto.Unmarshal.
+type ProtoCodec interface {
+	Marshal(message interface{}) ([]byte, error)
+	Unmarshal(data []byte, message interface{}) error
+}
+
+// A selfCodingMessage is a message which encodes itself (such as those
+// generated by gogo/protobuf).
+type selfCodingMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal(data []byte) error
+}
+
+// defaultProtoCodec relies on messages encoding themselves.
+type defaultProtoCodec struct{}
+
+func (defaultProtoCodec) Marshal(message interface{}) ([]byte, error) {
+	if m, ok := message.(selfCodingMessage); ok {
+		return m.Marshal()
+	}
+	return nil, fmt.Errorf("%T cannot marshal itself; set a ProtoCodec", message)
+}
+
+func (defaultProtoCodec) Unmarshal(data []byte, message interface{}) error {
+	if m, ok := message.(selfCodingMessage); ok {
+		return m.Unmarshal(data)
+	}
+	return fmt.Errorf("%T cannot unmarshal itself; set a ProtoCodec", message)
+}
+
+// WithProtoCodec sets the codec used by CallProtobuf and for protobuf
+// responses decoded through the connection (see Connection.Unmarshal).
+// By default messages must provide their own Marshal and Unmarshal
+// methods.
+func WithProtoCodec(codec ProtoCodec) Option {
+	return func(conn *Connection) {
+		conn.protoCodec = codec
+	}
+}
+
+// ProtoCodec returns the codec the connection encodes and decodes
+// protobuf messages with.
+func (conn *Connection) ProtoCodec() ProtoCodec {
+	if conn.protoCodec == nil {
+		return defaultProtoCodec{}
+	}
+	return conn.protoCodec
+}
+
+// SetInputProtobuf stores an encoded protobuf message (base64) as
+// input_protobuf_encoded, the protobuf counterpart of input_json.
+func (p *Parameters) SetInputProtobuf(encoded []byte) {
+	p.Values.Set("input_protobuf_encoded", base64.StdEncoding.EncodeToString(encoded))
+}
+
+// CallProtobuf calls a service method with request encoded as
+// input_protobuf_encoded, and decodes the binary protobuf response into
+// response (which must be a pointer to a message).
+func (conn *Connection) CallProtobuf(ctx context.Context, verb string, uri string, request interface{}, response interface{}, requireKey bool) error {
+	codec := conn.ProtoCodec()
+	encoded, err := codec.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	params := NewParameters()
+	params.SetInputProtobuf(encoded)
+	params.SetFormat(FormatProtobuf)
+
+	content, err := conn.Do(ctx, conn.NewRequest(verb, uri, params, requireKey))
+	if err != nil {
+		return err
+	}
+	return codec.Unmarshal(content, response)
+}
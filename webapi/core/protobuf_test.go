@@ -0,0 +1,159 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// message stands in for a protoc generated message.
+type message struct {
+	Text string
+}
+
+// textCodec encodes a *message as its text.
+type textCodec struct{}
+
+func (textCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(*message)
+	if !ok {
+		return nil, fmt.Errorf("textCodec cannot marshal %T", v)
+	}
+	return []byte(m.Text), nil
+}
+
+func (textCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(*message)
+	if !ok {
+		return fmt.Errorf("textCodec cannot unmarshal %T", v)
+	}
+	m.Text = string(data)
+	return nil
+}
+
+// selfCoding stands in for a gogo/protobuf message.
+type selfCoding struct {
+	Text string
+}
+
+func (m *selfCoding) Marshal() ([]byte, error) {
+	return []byte(m.Text), nil
+}
+
+func (m *selfCoding) Unmarshal(data []byte) error {
+	m.Text = "self:" + string(data)
+	return nil
+}
+
+// protoServer replies to input_protobuf_encoded requests with
+// "reply to <request>".
+func protoServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if format := r.FormValue("format"); format != string(FormatProtobuf) {
+			t.Errorf("format = %s, want %s", format, FormatProtobuf)
+		}
+		request, err := base64.StdEncoding.DecodeString(r.FormValue("input_protobuf_encoded"))
+		if err != nil {
+			t.Errorf("input_protobuf_encoded: %v", err)
+		}
+		w.Write([]byte("reply to " + string(request)))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCallProtobuf(t *testing.T) {
+	server := protoServer(t)
+	ctx := context.Background()
+
+	conn := NewConnectionWithOptions("", WithBaseURI(server.URL), WithProtoCodec(textCodec{}))
+	var response message
+	if err := conn.CallProtobuf(ctx, "GET", "IPlayerService/Method/v1/", &message{Text: "hello"}, &response, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Text != "reply to hello" {
+		t.Errorf("got %q, want \"reply to hello\"", response.Text)
+	}
+
+	conn = NewConnectionWithOptions("", WithBaseURI(server.URL))
+	var selfResponse selfCoding
+	if err := conn.CallProtobuf(ctx, "POST", "IPlayerService/Method/v1/", &selfCoding{Text: "hello"}, &selfResponse, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selfResponse.Text != "self:reply to hello" {
+		t.Errorf("got %q, want \"self:reply to hello\"", selfResponse.Text)
+	}
+
+	// Without a codec, messages must encode themselves
+	if err := conn.CallProtobuf(ctx, "GET", "IPlayerService/Method/v1/", &message{Text: "hello"}, &response, false); err == nil {
+		t.Error("a message which cannot marshal itself was sent")
+	}
+}
+
+func TestDecodeProtobuf(t *testing.T) {
+	plain := NewConnectionWithOptions("")
+	codec := NewConnectionWithOptions("", WithProtoCodec(textCodec{}))
+
+	// The package functions only know self coding messages
+	var m message
+	if err := Unmarshal(FormatProtobuf, []byte("data"), &m); err == nil {
+		t.Error("Unmarshal decoded a message which cannot unmarshal itself")
+	}
+	var s selfCoding
+	if err := DecodeResponse(FormatProtobuf, []byte("data"), &s); err != nil || s.Text != "self:data" {
+		t.Errorf("DecodeResponse: got %q, %v", s.Text, err)
+	}
+
+	// Connections use their codec
+	for name, decode := range map[string]func(conn *Connection, data []byte, v interface{}) error{
+		"Unmarshal": func(conn *Connection, data []byte, v interface{}) error {
+			return conn.Unmarshal(FormatProtobuf, data, v)
+		},
+		"DecodeResponse": func(conn *Connection, data []byte, v interface{}) error {
+			return conn.DecodeResponse(FormatProtobuf, data, v)
+		},
+	} {
+		var m message
+		if err := decode(codec, []byte("data"), &m); err != nil || m.Text != "data" {
+			t.Errorf("%s with a codec: got %q, %v", name, m.Text, err)
+		}
+		if err := decode(plain, []byte("data"), &m); err == nil {
+			t.Errorf("%s without a codec decoded a message which cannot unmarshal itself", name)
+		}
+		var s selfCoding
+		if err := decode(plain, []byte("data"), &s); err != nil || s.Text != "self:data" {
+			t.Errorf("%s without a codec: got %q, %v", name, s.Text, err)
+		}
+	}
+
+	// Other formats are decoded as before
+	var g game
+	if err := codec.DecodeResponse(FormatJSON, readFixture(t, "game.json"), &g); err != nil || g.AppID != 220 {
+		t.Errorf("DecodeResponse(FormatJSON): got %+v, %v", g, err)
+	}
+}
+
+func TestCallAndDecodeProtobuf(t *testing.T) {
+	server := protoServer(t)
+	conn := NewConnectionWithOptions("", WithBaseURI(server.URL), WithProtoCodec(textCodec{}))
+
+	encoded, _ := textCodec{}.Marshal(&message{Text: "hello"})
+	params := NewParameters()
+	params.SetInputProtobuf(encoded)
+	params.SetFormat(FormatProtobuf)
+
+	got, err := CallAndDecode[message](context.Background(), conn, conn.NewRequest("GET", "IPlayerService/Method/v1/", params, false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Text != "reply to hello" {
+		t.Errorf("got %q, want \"reply to hello\"", got.Text)
+	}
+}
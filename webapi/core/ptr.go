@@ -0,0 +1,11 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+// Ptr returns a pointer to v, for setting the optional (pointer) fields
+// of generated methods inline, e.g. IncludeAppinfo: core.Ptr(false).
+func Ptr[T any](v T) *T {
+	return &v
+}
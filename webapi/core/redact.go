@@ -0,0 +1,64 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"net/url"
+	"strings"
+)
+
+// The header used to send the key when WithKeyInHeader is set.
+const keyHeader = "x-webapi-key"
+
+// WithKeyInHeader sends the key in the x-webapi-key header rather than
+// the query string (or form body), keeping it out of URLs and proxy logs.
+func WithKeyInHeader(enabled bool) Option {
+	return func(conn *Connection) {
+		conn.keyInHeader = enabled
+	}
+}
+
+// redact replaces every occurrence of the key in s.
+func (conn *Connection) redact(s string) string {
+	if conn.key == "" {
+		return s
+	}
+	return strings.Replace(s, conn.key, redacted, -1)
+}
+
+// redactedError hides the key in the message of the error it wraps.
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactError returns err with any occurrence of the key hidden.
+//
+// A *url.Error (as returned by net/http, holding the full URL) is copied
+// with its URL redacted, so it can still be matched with errors.As.
+func (conn *Connection) redactError(err error) error {
+	if err == nil || conn.key == "" || !strings.Contains(err.Error(), conn.key) {
+		return err
+	}
+	if urlErr, ok := err.(*url.Error); ok {
+		copied := *urlErr
+		copied.URL = conn.redact(copied.URL)
+		if !strings.Contains(copied.Error(), conn.key) {
+			return &copied
+		}
+	}
+	return &redactedError{
+		message: conn.redact(err.Error()),
+		err:     err,
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Request is an immutable description of a WebAPI call, built by
+// Connection.NewRequest and sent with Connection.Do.
+//
+// A Request holds its own copy of the parameters (never the key), so it
+// may be inspected, reused and shared between goroutines freely.
+type Request struct {
+	// The connection which built the request
+	conn *Connection
+
+	// HTTP verb
+	verb string
+
+	// Method URI, relative to the base URI
+	uri string
+
+	// Parameters (without the key)
+	params url.Values
+
+	// If true the key is attached when sending
+	requireKey bool
+}
+
+// NewRequest builds a request for uri (e.g. "ISteamUser/ResolveVanityURL/v1/").
+//
+// params is copied (and may be nil); the key is attached when the request is
+// sent if requireKey is set or the connection is a partner connection.
+func (conn *Connection) NewRequest(verb string, uri string, params *Parameters, requireKey bool) *Request {
+	values := url.Values{}
+	if params != nil {
+		for name, value := range params.Values {
+			if name != "key" {
+				values[name] = append([]string(nil), value...)
+			}
+		}
+	}
+
+	return &Request{
+		conn:       conn,
+		verb:       verb,
+		uri:        uri,
+		params:     values,
+		requireKey: requireKey || conn.partner,
+	}
+}
+
+// Verb returns the HTTP verb (GET or POST).
+func (req *Request) Verb() string {
+	return req.verb
+}
+
+// URI returns the method URI, relative to the base URI.
+func (req *Request) URI() string {
+	return req.uri
+}
+
+// RequiresKey returns true if the key is sent with the request.
+func (req *Request) RequiresKey() bool {
+	return req.requireKey
+}
+
+// Params returns a copy of the parameters (without the key).
+func (req *Request) Params() *Parameters {
+	params := NewParameters()
+	for name, value := range req.params {
+		params.Values[name] = append([]string(nil), value...)
+	}
+	return params
+}
+
+// URL returns the full URL the request is sent to, with the key redacted.
+func (req *Request) URL() string {
+	return req.conn.redact(req.url())
+}
+
+// Body returns the encoded body of a POST (empty for a GET), with the key redacted.
+func (req *Request) Body() string {
+	return req.conn.redact(req.body())
+}
+
+// Format returns the response format the request asks for ("" for the WebAPI default).
+func (req *Request) Format() Format {
+	if format := req.params.Get("format"); format != "" {
+		return Format(format)
+	}
+	return req.conn.format
+}
+
+// values returns the parameters to be sent, with the key and the
+// connection's format attached if required.
+func (req *Request) values() url.Values {
+	addKey := req.requireKey && !req.conn.keyInHeader
+	addFormat := req.conn.format != "" && req.params.Get("format") == ""
+	if !addKey && !addFormat {
+		return req.params
+	}
+
+	values := make(url.Values, len(req.params)+2)
+	for name, value := range req.params {
+		values[name] = value
+	}
+	if addKey {
+		values.Set("key", req.conn.key)
+	}
+	if addFormat {
+		values.Set("format", string(req.conn.format))
+	}
+	return values
+}
+
+// url returns the unredacted URL.
+func (req *Request) url() string {
+	uri := req.conn.baseURI + req.uri
+	if req.verb == http.MethodPost {
+		return uri
+	}
+	if query := req.values().Encode(); query != "" {
+		uri += "?" + query
+	}
+	return uri
+}
+
+// body returns the unredacted body.
+func (req *Request) body() string {
+	if req.verb != http.MethodPost {
+		return ""
+	}
+	return req.values().Encode()
+}
+
+// httpRequest builds the HTTP request to send.
+func (req *Request) httpRequest(ctx context.Context) (*http.Request, error) {
+	var body io.Reader
+	payload := req.body()
+	if req.verb == http.MethodPost {
+		body = bytes.NewBufferString(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, req.verb, req.url(), body)
+	if err != nil {
+		return nil, req.conn.redactError(err)
+	}
+
+	if req.requireKey && req.conn.keyInHeader {
+		request.Header.Set(keyHeader, req.conn.key)
+	}
+	if req.verb == http.MethodPost {
+		request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		request.Header.Add("Content-Length", strconv.Itoa(len(payload)))
+	}
+	return request, nil
+}
+
+// Do sends req and returns the body of the response.
+//
+// Cancelling ctx (or reaching its deadline) aborts the request; ctx.Err() is returned.
+// If the connection has a cache, fresh cached GET responses are returned without a request.
+func (conn *Connection) Do(ctx context.Context, req *Request) (content []byte, err error) {
+	cacheKey, ttl := "", time.Duration(0)
+	if req.verb == http.MethodGet {
+		cacheKey, ttl = conn.cacheEntry(req.uri, req.values())
+		if ttl > 0 {
+			if content, ok := conn.cache.Get(cacheKey); ok {
+				return content, nil
+			}
+		}
+	}
+
+	request, err := conn.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, request, false)
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		conn.cache.Set(cacheKey, reply.Body, ttl)
+	}
+	return reply.Body, nil
+}
+
+// prepare builds the HTTP request for req, which must have been built by conn.
+func (conn *Connection) prepare(ctx context.Context, req *Request) (*http.Request, error) {
+	if req.conn != conn {
+		return nil, errors.New("request was built by a different connection")
+	}
+	return req.httpRequest(ctx)
+}
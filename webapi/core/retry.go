@@ -0,0 +1,178 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// Only GET requests are retried unless RetryPost is set, or the call's
+// context has been marked with AllowRetry.
+type RetryPolicy struct {
+	// Total attempts (including the first); values below 2 disable retries
+	MaxAttempts int
+
+	// Delay before the first retry, doubled for each retry after that
+	BaseDelay time.Duration
+
+	// Upper bound for any single delay (including Retry-After); zero or
+	// less means no bound
+	MaxDelay time.Duration
+
+	// Fraction (0 to 1) of each delay which is randomised
+	Jitter float64
+
+	// Status codes treated as transient; nil means those of
+	// DefaultRetryPolicy (429, 500, 502 and 503), and an empty (non-nil)
+	// slice retries no HTTP failures, only transport errors
+	Statuses []int
+
+	// If true POST requests are retried as well
+	RetryPost bool
+}
+
+// DefaultRetryPolicy retries transient failures twice, starting at half a second.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.2,
+	Statuses: []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+	},
+}
+
+// WithRetryPolicy enables retries on the connection.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(conn *Connection) {
+		conn.retry = &policy
+	}
+}
+
+type allowRetryKey struct{}
+
+// AllowRetry marks calls made with the returned context as safe to retry,
+// which lets POST requests be retried under the connection's policy.
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowRetryKey{}, true)
+}
+
+// attempts returns the number of attempts permitted for a request.
+func (policy *RetryPolicy) attempts(ctx context.Context, method string) int {
+	if policy == nil || policy.MaxAttempts < 2 {
+		return 1
+	}
+	if method == http.MethodGet || method == http.MethodHead || policy.RetryPost {
+		return policy.MaxAttempts
+	}
+	if allowed, _ := ctx.Value(allowRetryKey{}).(bool); allowed {
+		return policy.MaxAttempts
+	}
+	return 1
+}
+
+// retryable reports whether a failed attempt is worth repeating.
+func (policy *RetryPolicy) retryable(err error) bool {
+	if errors.Is(err, ErrResponseTooLarge) || errors.Is(err, ErrResult) {
+		return false
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// Transport failure (cancellation is filtered out by the caller)
+		return true
+	}
+	statuses := policy.Statuses
+	if statuses == nil {
+		statuses = DefaultRetryPolicy.Statuses
+	}
+	for _, status := range statuses {
+		if apiErr.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+// The most times the base delay is doubled
+const maxBackoffShift = 30
+
+// delay returns the wait before the given retry (1 being the first),
+// honouring any Retry-After header on the failed response.
+func (policy *RetryPolicy) delay(retry int, err error) time.Duration {
+	// Doubling stops after maxBackoffShift retries, and saturates rather
+	// than overflowing
+	shift := retry - 1
+	if shift < 0 {
+		shift = 0
+	}
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	delay := policy.BaseDelay << uint(shift)
+	if policy.BaseDelay > 0 && delay>>uint(shift) != policy.BaseDelay {
+		delay = math.MaxInt64
+	}
+	if policy.Jitter > 0 {
+		spread := float64(delay) * policy.Jitter
+		jittered := float64(delay) + spread*(2*rand.Float64()-1)
+		if jittered >= math.MaxInt64 {
+			delay = math.MaxInt64
+		} else {
+			delay = time.Duration(jittered)
+		}
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if after, ok := retryAfter(apiErr.Header.Get("Retry-After")); ok {
+			delay = after
+		}
+	}
+
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return delay
+}
+
+// retryAfter parses a Retry-After value (either seconds or an HTTP date).
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when), true
+	}
+	return 0, false
+}
+
+// sleep waits for d, returning early with ctx.Err() if ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
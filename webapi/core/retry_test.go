@@ -0,0 +1,142 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with status, then
+// answers "ok". The number of requests seen is returned alongside.
+func flakyServer(t *testing.T, failures int, status int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(atomic.AddInt32(&calls, 1)) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// fastPolicy is policy without the waits.
+func fastPolicy(policy RetryPolicy) RetryPolicy {
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		post     bool
+		allow    bool
+		failures int
+		status   int
+		calls    int32
+		success  bool
+	}{
+		{"default retries 503 twice", DefaultRetryPolicy, false, false, 2, http.StatusServiceUnavailable, 3, true},
+		{"default gives up after 3 attempts", DefaultRetryPolicy, false, false, 3, http.StatusServiceUnavailable, 3, false},
+		{"default does not retry 404", DefaultRetryPolicy, false, false, 1, http.StatusNotFound, 1, false},
+		{"default does not retry POST", DefaultRetryPolicy, true, false, 1, http.StatusServiceUnavailable, 1, false},
+		{"AllowRetry retries POST", DefaultRetryPolicy, true, true, 2, http.StatusServiceUnavailable, 3, true},
+		{"RetryPost retries POST", RetryPolicy{MaxAttempts: 3, RetryPost: true}, true, false, 2, http.StatusBadGateway, 3, true},
+		{"nil statuses use the defaults", RetryPolicy{MaxAttempts: 3}, false, false, 2, http.StatusTooManyRequests, 3, true},
+		{"empty statuses retry no HTTP failures", RetryPolicy{MaxAttempts: 3, Statuses: []int{}}, false, false, 1, http.StatusServiceUnavailable, 1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, calls := flakyServer(t, test.failures, test.status)
+			conn := NewConnectionWithOptions("", WithBaseURI(server.URL), WithRetryPolicy(fastPolicy(test.policy)))
+
+			ctx := context.Background()
+			if test.allow {
+				ctx = AllowRetry(ctx)
+			}
+			var content []byte
+			var err error
+			if test.post {
+				content, err = conn.PostContext(ctx, "ITest/Method/v1/", NewParameters(), false)
+			} else {
+				content, err = conn.GetContext(ctx, "ITest/Method/v1/", NewParameters(), false)
+			}
+
+			if got := atomic.LoadInt32(calls); got != test.calls {
+				t.Errorf("made %d requests, want %d", got, test.calls)
+			}
+			if test.success {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if string(content) != "ok" {
+					t.Errorf("got %q, want \"ok\"", content)
+				}
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got %v, want an *APIError", err)
+			}
+			if apiErr.StatusCode != test.status {
+				t.Errorf("got status %d, want %d", apiErr.StatusCode, test.status)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	const forever = time.Duration(math.MaxInt64)
+	second := RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute}
+	uncapped := RetryPolicy{BaseDelay: time.Second}
+	huge := RetryPolicy{BaseDelay: forever / 4}
+
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		{"first retry", second, 1, time.Second},
+		{"doubles", second, 3, 4 * time.Second},
+		{"capped", second, 10, time.Minute},
+		{"capped after many retries", second, 1000, time.Minute},
+		{"capped at the largest retry", second, math.MaxInt32, time.Minute},
+		{"zero retry", second, 0, time.Second},
+		{"negative retry", second, -5, time.Second},
+		{"no cap", uncapped, 10, 512 * time.Second},
+		{"no cap stops doubling", uncapped, 1000, time.Second << maxBackoffShift},
+		{"no cap saturates", huge, 100, forever},
+		{"zero base", RetryPolicy{}, 100, 0},
+	}
+
+	for _, test := range tests {
+		if got := test.policy.delay(test.retry, nil); got != test.want {
+			t.Errorf("%s: delay(%d) = %s, want %s", test.name, test.retry, got, test.want)
+		}
+	}
+}
+
+func TestRetryDelayJitter(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+	for retry := 1; retry < 100; retry++ {
+		got := policy.delay(retry, nil)
+		if got <= 0 {
+			t.Fatalf("delay(%d) = %s, want a positive delay", retry, got)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrResponseTooLarge is matched (via errors.Is) by every *ResponseTooLargeError.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ResponseTooLargeError is returned when a response exceeds the
+// connection's maximum response size.
+type ResponseTooLargeError struct {
+	// The limit which was exceeded (in bytes)
+	Limit int64
+
+	// The request URI (with the key stripped)
+	URI string
+}
+
+// Error implements error.
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response from '%s' exceeds %d bytes", e.URI, e.Limit)
+}
+
+// Is reports whether target is ErrResponseTooLarge.
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// WithMaxResponseSize limits response bodies to size bytes (zero, the
+// default, means no limit); larger responses fail with a *ResponseTooLargeError.
+func WithMaxResponseSize(size int64) Option {
+	return func(conn *Connection) {
+		conn.maxResponseSize = size
+	}
+}
+
+// tooLarge returns the error for an oversized response to request.
+func (conn *Connection) tooLarge(request *http.Request) error {
+	return &ResponseTooLargeError{
+		Limit: conn.maxResponseSize,
+		URI:   stripKey(request.URL.String()),
+	}
+}
+
+// limitBody applies the connection's maximum response size to body.
+func (conn *Connection) limitBody(request *http.Request, body io.ReadCloser) io.ReadCloser {
+	if conn.maxResponseSize <= 0 {
+		return body
+	}
+	return &limitedBody{
+		body:      body,
+		remaining: conn.maxResponseSize,
+		err:       conn.tooLarge(request),
+	}
+}
+
+// limitedBody fails with err once more than the permitted bytes are read.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (l *limitedBody) Read(p []byte) (n int, err error) {
+	if l.remaining < 0 {
+		return 0, l.err
+	}
+	// Read one byte beyond the limit to tell "exactly the limit" from "over it"
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err = l.body.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), l.err
+	}
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	return l.body.Close()
+}
+
+// Stream sends req and returns the unread body of the response, which the
+// caller must close. Streamed responses are never cached; ctx must remain
+// live until the body has been read.
+func (conn *Connection) Stream(ctx context.Context, req *Request) (body io.ReadCloser, err error) {
+	request, err := conn.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, request, true)
+	if err != nil {
+		return nil, err
+	}
+	return reply.Stream, nil
+}
+
+// DecodeJSON sends req and decodes the JSON response straight into v,
+// without holding the whole body in memory.
+func (conn *Connection) DecodeJSON(ctx context.Context, req *Request, v interface{}) error {
+	body, err := conn.Stream(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// node is a format-neutral element of an XML or VDF document.
+type node struct {
+	// Element (or key) name
+	name string
+
+	// Text of a leaf
+	value string
+
+	// Nested elements, in document order
+	children []*node
+
+	// If true the node holds a value rather than children
+	leaf bool
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decodeTree decodes the children of root into v (a non-nil pointer).
+func decodeTree(root *node, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("decode target must be a non-nil pointer")
+	}
+	return decodeNode(root, rv.Elem())
+}
+
+// decodeNode decodes n into v.
+func decodeNode(n *node, v reflect.Value) error {
+	if n.leaf && v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(n.value))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeNode(n, v.Elem())
+
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf(n.generic()))
+		}
+		return nil
+
+	case reflect.Struct:
+		fields := fieldIndexes(v.Type())
+		for _, child := range n.children {
+			index, ok := fields[strings.ToLower(child.name)]
+			if !ok {
+				continue
+			}
+			if err := decodeNode(child, fieldByIndex(v, index)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if n.leaf {
+			if v.Type().Elem().Kind() == reflect.Uint8 {
+				v.SetBytes([]byte(n.value))
+			}
+			return nil
+		}
+		slice := reflect.MakeSlice(v.Type(), 0, len(n.children))
+		for _, child := range n.children {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeNode(child, elem); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		v.Set(slice)
+		return nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("cannot decode '%s' into %s", n.name, v.Type())
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for _, child := range n.children {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeNode(child, elem); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(child.name).Convert(v.Type().Key()), elem)
+		}
+		return nil
+	}
+
+	if !n.leaf {
+		return fmt.Errorf("cannot decode element '%s' into %s", n.name, v.Type())
+	}
+	return setScalar(n, v)
+}
+
+// setScalar converts the text of a leaf to the kind of v.
+func setScalar(n *node, v reflect.Value) error {
+	value := strings.TrimSpace(n.value)
+	var err error
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(n.value)
+		return nil
+	case reflect.Bool:
+		var b bool
+		if value != "" {
+			b, err = strconv.ParseBool(value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if value != "" {
+			i, err = strconv.ParseInt(value, 10, v.Type().Bits())
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		if value != "" {
+			u, err = strconv.ParseUint(value, 10, v.Type().Bits())
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if value != "" {
+			f, err = strconv.ParseFloat(value, v.Type().Bits())
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot decode '%s' into %s", n.name, v.Type())
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot decode '%s' value '%s' into %s", n.name, value, v.Type())
+	}
+	return nil
+}
+
+// fieldIndexes maps the lower-cased json names of a struct's fields to
+// their indexes (fields of embedded structs included).
+func fieldIndexes(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for name, index := range fieldIndexes(embedded) {
+					if _, ok := fields[name]; !ok {
+						fields[name] = append([]int{i}, index...)
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = []int{i}
+	}
+	return fields
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex, allocating nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// generic converts n into strings and map[string]interface{} values.
+func (n *node) generic() interface{} {
+	if n.leaf {
+		return n.value
+	}
+	m := make(map[string]interface{}, len(n.children))
+	for _, child := range n.children {
+		m[child.name] = child.generic()
+	}
+	return m
+}
@@ -0,0 +1,174 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/awstanley/GoSteam/steamid"
+)
+
+// Types for declaring response structs against what the WebAPI sends.
+// Each decodes from JSON, XML and VDF alike; missing fields are left as
+// the zero value.
+
+// SteamID is a SteamID sent as a string or a number (see steamid.SteamID).
+type SteamID = steamid.SteamID
+
+// jsonText returns the text of a JSON string or other literal, and
+// whether it was null.
+func jsonText(data []byte) (string, bool, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", true, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		s, err := strconv.Unquote(string(data))
+		return s, false, err
+	}
+	return string(data), false, nil
+}
+
+// StringUint64 is a 64-bit value the WebAPI sends as a string (so
+// JavaScript doesn't lose precision), e.g. publishedfileid; numbers are
+// accepted too.
+type StringUint64 uint64
+
+// String returns the value in decimal.
+func (v StringUint64) String() string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v StringUint64) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; "" is zero.
+func (v *StringUint64) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		*v = 0
+		return nil
+	}
+	value, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid 64-bit value '%s'", s)
+	}
+	*v = StringUint64(value)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler (as a string).
+func (v StringUint64) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(v.String())), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *StringUint64) UnmarshalJSON(data []byte) error {
+	s, null, err := jsonText(data)
+	if err != nil || null {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// UnixTime is a time the WebAPI sends in Unix seconds (e.g. lastlogoff);
+// 0 is the zero time.
+type UnixTime struct {
+	time.Time
+}
+
+// Unix returns the time in Unix seconds (0 for the zero time).
+func (t UnixTime) Unix() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Time.Unix()
+}
+
+// MarshalText implements encoding.TextMarshaler (as Unix seconds).
+func (t UnixTime) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; "" is the zero time.
+func (t *UnixTime) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" || s == "0" {
+		t.Time = time.Time{}
+		return nil
+	}
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid Unix time '%s'", s)
+	}
+	t.Time = time.Unix(seconds, 0)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler (as a number).
+func (t UnixTime) MarshalJSON() ([]byte, error) {
+	return t.MarshalText()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a number or string.
+func (t *UnixTime) UnmarshalJSON(data []byte) error {
+	s, null, err := jsonText(data)
+	if err != nil || null {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// IntBool is a boolean the WebAPI sends as 0 or 1 (e.g. profilestate);
+// true and false are accepted too.
+type IntBool bool
+
+// MarshalText implements encoding.TextMarshaler (as 0 or 1).
+func (b IntBool) MarshalText() ([]byte, error) {
+	if b {
+		return []byte("1"), nil
+	}
+	return []byte("0"), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; "" is false, and any
+// non-zero number is true.
+func (b *IntBool) UnmarshalText(text []byte) error {
+	s := strings.ToLower(strings.TrimSpace(string(text)))
+	switch s {
+	case "", "0", "false":
+		*b = false
+		return nil
+	case "1", "true":
+		*b = true
+		return nil
+	}
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid boolean '%s'", s)
+	}
+	*b = value != 0
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler (as 0 or 1).
+func (b IntBool) MarshalJSON() ([]byte, error) {
+	return b.MarshalText()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *IntBool) UnmarshalJSON(data []byte) error {
+	s, null, err := jsonText(data)
+	if err != nil || null {
+		return err
+	}
+	return b.UnmarshalText([]byte(s))
+}
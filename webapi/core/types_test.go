@@ -0,0 +1,100 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// typed is decoded from every format in TestTypes.
+type typed struct {
+	Public  IntBool      `json:"public"`
+	Private IntBool      `json:"private"`
+	Banned  IntBool      `json:"banned"`
+	FileID  StringUint64 `json:"publishedfileid"`
+	Updated UnixTime     `json:"updated"`
+	Never   UnixTime     `json:"never"`
+	Owner   SteamID      `json:"owner"`
+	Nobody  SteamID      `json:"nobody"`
+}
+
+var wantTyped = typed{
+	Public:  true,
+	Private: false,
+	Banned:  true,
+	FileID:  18446744073709551615,
+	Updated: UnixTime{time.Unix(1456790400, 0)},
+	Owner:   76561197960287930,
+}
+
+func TestTypes(t *testing.T) {
+	tests := []struct {
+		format Format
+		body   string
+	}{
+		{FormatJSON, `{"public": 1, "private": "0", "banned": true, "publishedfileid": "18446744073709551615",
+			"updated": 1456790400, "never": 0, "owner": "76561197960287930", "nobody": ""}`},
+		{FormatJSON, `{"public": "true", "private": false, "banned": 2, "publishedfileid": 18446744073709551615,
+			"updated": "1456790400", "never": null, "owner": 76561197960287930, "nobody": null}`},
+		{FormatXML, `<response><public>1</public><private>0</private><banned>true</banned>
+			<publishedfileid>18446744073709551615</publishedfileid><updated>1456790400</updated>
+			<never></never><owner>76561197960287930</owner><nobody/></response>`},
+		{FormatVDF, `"response" { "public" "1" "private" "" "banned" "1" "publishedfileid" "18446744073709551615"
+			"updated" "1456790400" "never" "0" "owner" "[U:1:22202]" "nobody" "" }`},
+	}
+
+	for _, test := range tests {
+		var got typed
+		if err := DecodeResponse(test.format, []byte(test.body), &got); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.format, err)
+			continue
+		}
+		if !got.Updated.Equal(wantTyped.Updated.Time) {
+			t.Errorf("%s: got updated %s, want %s", test.format, got.Updated, wantTyped.Updated)
+		}
+		got.Updated = wantTyped.Updated
+		if got != wantTyped {
+			t.Errorf("%s:\ngot  %+v\nwant %+v", test.format, got, wantTyped)
+		}
+	}
+}
+
+func TestTypesInvalid(t *testing.T) {
+	for _, body := range []string{
+		`{"public": "yes"}`,
+		`{"publishedfileid": "-1"}`,
+		`{"publishedfileid": "1e3"}`,
+		`{"updated": "yesterday"}`,
+		`{"owner": "gabe"}`,
+	} {
+		var v typed
+		if err := Unmarshal(FormatJSON, []byte(body), &v); err == nil {
+			t.Errorf("%s: got %+v, want an error", body, v)
+		}
+	}
+}
+
+func TestTypesMarshal(t *testing.T) {
+	v := wantTyped
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"public":1,"private":0,"banned":1,"publishedfileid":"18446744073709551615",` +
+		`"updated":1456790400,"never":0,"owner":"76561197960287930","nobody":"0"}`
+	if string(data) != want {
+		t.Errorf("got  %s\nwant %s", data, want)
+	}
+
+	var back typed
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != v {
+		t.Errorf("round trip gave %+v", back)
+	}
+}
@@ -5,8 +5,13 @@
 package core
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/awstanley/GoSteam/steamid"
 )
 
 // Parameters is a trivial wrapper around url.Values
@@ -69,3 +74,86 @@ func (p *Parameters) AddBoolean(name string, value bool) {
 		p.Values.Add(name, "false")
 	}
 }
+
+// joinList joins formatted values with commas.
+func joinList(n int, format func(i int) string) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = format(i)
+	}
+	return strings.Join(parts, ",")
+}
+
+// AddStringList adds a comma-delimited list of strings.
+func (p *Parameters) AddStringList(name string, values []string) {
+	p.Values.Add(name, strings.Join(values, ","))
+}
+
+// AddUInt32List adds a comma-delimited list of uint32s.
+func (p *Parameters) AddUInt32List(name string, values []uint32) {
+	p.Values.Add(name, joinList(len(values), func(i int) string {
+		return strconv.FormatUint(uint64(values[i]), 10)
+	}))
+}
+
+// AddUInt64List adds a comma-delimited list of uint64s.
+func (p *Parameters) AddUInt64List(name string, values []uint64) {
+	p.Values.Add(name, joinList(len(values), func(i int) string {
+		return strconv.FormatUint(values[i], 10)
+	}))
+}
+
+// AddSteamIDList adds a comma-delimited list of SteamIDs (e.g. steamids).
+func (p *Parameters) AddSteamIDList(name string, ids []steamid.SteamID) {
+	p.Values.Add(name, joinList(len(ids), func(i int) string {
+		return ids[i].String()
+	}))
+}
+
+// AddStringArray adds an indexed array (name[0], name[1], ...) of strings.
+//
+// Methods taking an array usually take its length too (e.g. itemcount);
+// that has to be added separately.
+func (p *Parameters) AddStringArray(name string, values []string) {
+	for i, value := range values {
+		p.Values.Add(fmt.Sprintf("%s[%d]", name, i), value)
+	}
+}
+
+// AddUInt32Array adds an indexed array (name[0], name[1], ...) of uint32s.
+func (p *Parameters) AddUInt32Array(name string, values []uint32) {
+	for i, value := range values {
+		p.AddUInt32(fmt.Sprintf("%s[%d]", name, i), value)
+	}
+}
+
+// AddUInt64Array adds an indexed array (name[0], name[1], ...) of uint64s.
+func (p *Parameters) AddUInt64Array(name string, values []uint64) {
+	for i, value := range values {
+		p.AddUInt64(fmt.Sprintf("%s[%d]", name, i), value)
+	}
+}
+
+// AddSteamIDArray adds an indexed array (name[0], name[1], ...) of SteamIDs.
+func (p *Parameters) AddSteamIDArray(name string, ids []steamid.SteamID) {
+	for i, id := range ids {
+		p.Values.Add(fmt.Sprintf("%s[%d]", name, i), id.String())
+	}
+}
+
+// AddInt64 adds an int64
+func (p *Parameters) AddInt64(name string, value int64) {
+	p.Values.Add(name, fmt.Sprintf("%d", value))
+}
+
+// SetInputJSON encodes v as JSON and stores it as input_json, the single
+// parameter taken by service interfaces (e.g. IPlayerService) in place of
+// flat parameters. It is sent as-is for both GET and POST.
+func (p *Parameters) SetInputJSON(v interface{}) error {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	p.Values.Set("input_json", string(encoded))
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidParameters is matched (via errors.Is) by every *ValidationError.
+var ErrInvalidParameters = errors.New("invalid parameters")
+
+// ValidationError is returned (before anything is sent) when a request's
+// parameters are missing or cannot be sent as they are.
+type ValidationError struct {
+	// Required parameters with no value
+	Missing []string
+
+	// Parameters which cannot be sent, as "name: reason"
+	Invalid []string
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing required parameters: "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		parts = append(parts, "invalid parameters: "+strings.Join(e.Invalid, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Is reports whether target is ErrInvalidParameters.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidParameters
+}
+
+// IsInvalidParameters reports whether err is (or wraps) a *ValidationError.
+func IsInvalidParameters(err error) bool {
+	return errors.Is(err, ErrInvalidParameters)
+}
+
+// Validate checks the tagged fields of a struct (or pointer to one), as
+// encoded by EncodeParameters or SetInputJSON, returning a
+// *ValidationError if any are missing or invalid.
+//
+// Fields tagged `steam:"name"` without optional, or `json:"name"` without
+// omitempty, are required; empty strings, empty slices and nil pointers
+// count as missing.  Numbers and booleans are always present, as zero is
+// usually meaningful, except for IDs: a zero number tagged nonzero (e.g.
+// `steam:"appid,nonzero"`, as generated for SteamIDs, app IDs and the
+// like) counts as missing too, and lists of them must not hold zeros.
+// Floats must be finite, strings valid UTF-8, and the elements of
+// comma-delimited lists must not contain commas; integers are not range
+// checked, as generated fields have the type the WebAPI declares.
+func Validate(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return fmt.Errorf("cannot validate nil %s", value.Type())
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot validate %s as parameters", value.Type())
+	}
+
+	e := &ValidationError{}
+	e.check(value)
+	if len(e.Missing) > 0 || len(e.Invalid) > 0 {
+		return e
+	}
+	return nil
+}
+
+// validationTag returns the parsed tag of a field (steam, falling back to
+// json, whose omitempty means optional), and whether it is one.
+func validationTag(field reflect.StructField) (paramTag, bool) {
+	if tag, ok := field.Tag.Lookup("steam"); ok {
+		if tag == "-" {
+			return paramTag{}, false
+		}
+		return parseParamTag(tag), true
+	}
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if tag == "-" {
+			return paramTag{}, false
+		}
+		parts := strings.Split(tag, ",")
+		out := paramTag{name: parts[0]}
+		for _, option := range parts[1:] {
+			switch option {
+			case "omitempty":
+				out.optional = true
+			case "nonzero":
+				out.nonzero = true
+			}
+		}
+		return out, true
+	}
+	return paramTag{}, false
+}
+
+func (e *ValidationError) check(value reflect.Value) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		options, tagged := validationTag(field)
+
+		if field.Anonymous && !tagged {
+			embedded := value.Field(i)
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				e.check(embedded)
+			}
+			continue
+		}
+
+		if !tagged || field.PkgPath != "" {
+			continue
+		}
+		if options.name == "" {
+			options.name = field.Name
+		}
+		e.checkField(options, value.Field(i))
+	}
+}
+
+func (e *ValidationError) checkField(options paramTag, value reflect.Value) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			if !options.optional {
+				e.Missing = append(e.Missing, options.name)
+			}
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		if value.Len() == 0 && !options.optional {
+			e.Missing = append(e.Missing, options.name)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.IsZero() && !options.optional && options.nonzero {
+			e.Missing = append(e.Missing, options.name)
+		}
+	case reflect.Slice, reflect.Array:
+		if value.Len() == 0 && !options.optional {
+			e.Missing = append(e.Missing, options.name)
+		}
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			elem := value.Index(i)
+			if options.list && elem.Kind() == reflect.String && strings.Contains(elem.String(), ",") {
+				e.invalid(options.name, "element %d contains a comma", i)
+				continue
+			}
+			if options.nonzero && isZeroNumber(elem) {
+				e.invalid(options.name, "element %d is zero", i)
+				continue
+			}
+			e.checkRange(fmt.Sprintf("%s[%d]", options.name, i), elem)
+		}
+		return
+	}
+	e.checkRange(options.name, value)
+}
+
+// isZeroNumber reports whether value is an integer holding zero.
+func isZeroNumber(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.IsZero()
+	}
+	return false
+}
+
+// checkRange notes floats which are not finite and strings which are not
+// valid UTF-8.
+func (e *ValidationError) checkRange(name string, value reflect.Value) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if f := value.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			e.invalid(name, "%v is not a finite number", f)
+		}
+	case reflect.String:
+		if !utf8.ValidString(value.String()) {
+			e.invalid(name, "not valid UTF-8")
+		}
+	}
+}
+
+func (e *ValidationError) invalid(name string, format string, args ...interface{}) {
+	e.Invalid = append(e.Invalid, name+": "+fmt.Sprintf(format, args...))
+}
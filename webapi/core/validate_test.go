@@ -0,0 +1,110 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/awstanley/GoSteam/steamid"
+)
+
+// ids has the fields generated for a method taking IDs.
+type ids struct {
+	SteamID    steamid.SteamID   `steam:"steamid,nonzero"`
+	AppID      *uint32           `steam:"appid,optional,nonzero"`
+	Count      uint32            `steam:"count"`
+	Items      []uint64          `steam:"publishedfileids,count=itemcount,nonzero"`
+	SteamIDs   []steamid.SteamID `steam:"steamids,list,nonzero"`
+	InstanceID uint64            `steam:"instanceid"`
+}
+
+// service has the fields generated for a service method (sent as
+// input_json).
+type service struct {
+	SteamID steamid.SteamID `json:"steamid,nonzero"`
+	AppIDs  []uint32        `json:"appids,omitempty,nonzero"`
+	Name    string          `json:"name"`
+	Score   float32         `json:"score,omitempty"`
+}
+
+func TestValidate(t *testing.T) {
+	zero := uint32(0)
+	valid := ids{
+		SteamID:  76561197960287930,
+		Items:    []uint64{1},
+		SteamIDs: []steamid.SteamID{76561197960287930},
+	}
+
+	tests := []struct {
+		name    string
+		v       interface{}
+		missing []string
+		invalid []string
+	}{
+		{"valid", valid, nil, nil},
+		{"zero IDs are missing", ids{}, []string{"steamid", "publishedfileids", "steamids"}, nil},
+		{
+			"zero list elements are invalid",
+			ids{SteamID: 1, Items: []uint64{1, 0}, SteamIDs: []steamid.SteamID{0}},
+			nil,
+			[]string{"publishedfileids: element 1 is zero", "steamids: element 0 is zero"},
+		},
+		{
+			"explicit optional zero is sent",
+			ids{SteamID: 1, AppID: &zero, Items: []uint64{1}, SteamIDs: []steamid.SteamID{1}},
+			nil,
+			nil,
+		},
+		{"valid service", service{SteamID: 1, Name: "x"}, nil, nil},
+		{"zero service ID is missing", service{Name: "x"}, []string{"steamid"}, nil},
+		{
+			"service list elements and floats",
+			service{SteamID: 1, Name: "x", AppIDs: []uint32{0}, Score: float32(math.Inf(1))},
+			nil,
+			[]string{"appids: element 0 is zero", "score: +Inf is not a finite number"},
+		},
+		{
+			"untagged numbers are not IDs",
+			struct {
+				AppID uint32 `steam:"appid"`
+			}{},
+			nil,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		err := Validate(test.v)
+		if test.missing == nil && test.invalid == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || !IsInvalidParameters(err) {
+			t.Errorf("%s: got %v, want a *ValidationError", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(validationErr.Missing, test.missing) {
+			t.Errorf("%s: missing %q, want %q", test.name, validationErr.Missing, test.missing)
+		}
+		if !reflect.DeepEqual(validationErr.Invalid, test.invalid) {
+			t.Errorf("%s: invalid %q, want %q", test.name, validationErr.Invalid, test.invalid)
+		}
+	}
+}
+
+func TestParseParamTag(t *testing.T) {
+	got := parseParamTag("publishedfileids,optional,list,count=itemcount,nonzero")
+	want := paramTag{name: "publishedfileids", optional: true, list: true, count: "itemcount", nonzero: true}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VDF (KeyValues) tokens
+const (
+	vdfEOF = iota
+	vdfString
+	vdfOpen
+	vdfClose
+)
+
+// vdfScanner tokenises VDF text.
+type vdfScanner struct {
+	data []byte
+	pos  int
+	line int
+}
+
+// parseVDF reads a VDF (KeyValues) document into a tree; top level keys
+// become children of the returned root.
+func parseVDF(data []byte) (*node, error) {
+	scanner := &vdfScanner{data: data, line: 1}
+	root := &node{}
+	if err := scanner.block(root, false); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// block reads key/value pairs into parent until the closing brace (or EOF at the top level).
+func (s *vdfScanner) block(parent *node, nested bool) error {
+	for {
+		kind, key, err := s.next()
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case vdfEOF:
+			if nested {
+				return fmt.Errorf("vdf: unexpected end of input in '%s'", parent.name)
+			}
+			return nil
+		case vdfClose:
+			if !nested {
+				return fmt.Errorf("vdf: unexpected '}' on line %d", s.line)
+			}
+			return nil
+		case vdfOpen:
+			return fmt.Errorf("vdf: unexpected '{' on line %d", s.line)
+		}
+
+		kind, value, err := s.next()
+		if err != nil {
+			return err
+		}
+		child := &node{name: key}
+		parent.children = append(parent.children, child)
+		switch kind {
+		case vdfString:
+			child.value = value
+			child.leaf = true
+		case vdfOpen:
+			if err := s.block(child, true); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("vdf: missing value for '%s' on line %d", key, s.line)
+		}
+	}
+}
+
+// next returns the next token.
+func (s *vdfScanner) next() (kind int, text string, err error) {
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		switch {
+		case c == '\n':
+			s.line++
+			s.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			s.pos++
+		case c == '/' && s.pos+1 < len(s.data) && s.data[s.pos+1] == '/':
+			for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+				s.pos++
+			}
+		case c == '{':
+			s.pos++
+			return vdfOpen, "", nil
+		case c == '}':
+			s.pos++
+			return vdfClose, "", nil
+		case c == '"':
+			return s.quoted()
+		default:
+			start := s.pos
+			for s.pos < len(s.data) && !strings.ContainsRune(" \t\r\n{}\"", rune(s.data[s.pos])) {
+				s.pos++
+			}
+			return vdfString, string(s.data[start:s.pos]), nil
+		}
+	}
+	return vdfEOF, "", nil
+}
+
+// quoted reads a quoted string, handling escapes.
+func (s *vdfScanner) quoted() (kind int, text string, err error) {
+	var out strings.Builder
+	s.pos++ // opening quote
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		s.pos++
+		switch c {
+		case '"':
+			return vdfString, out.String(), nil
+		case '\n':
+			s.line++
+			out.WriteByte(c)
+		case '\\':
+			if s.pos >= len(s.data) {
+				break
+			}
+			escaped := s.data[s.pos]
+			s.pos++
+			switch escaped {
+			case 'n':
+				out.WriteByte('\n')
+			case 't':
+				out.WriteByte('\t')
+			default:
+				out.WriteByte(escaped)
+			}
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return vdfEOF, "", fmt.Errorf("vdf: unterminated string on line %d", s.line)
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package core
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+)
+
+// parseXML reads an XML document into a tree; the document element
+// becomes the only child of the returned root.
+func parseXML(data []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	root := &node{}
+	stack := []*node{root}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		top := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			child := &node{name: t.Name.Local}
+			top.children = append(top.children, child)
+			stack = append(stack, child)
+		case xml.CharData:
+			top.value += string(t)
+		case xml.EndElement:
+			top.leaf = len(top.children) == 0
+			if !top.leaf {
+				top.value = ""
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return root, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package resolver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/awstanley/GoSteam/steamid"
+)
+
+// Source is where a resolved SteamID came from.
+type Source int
+
+// The sources of a SteamID.
+const (
+	// A bare SteamID64 (76561197960287930)
+	SourceSteamID64 Source = iota + 1
+
+	// A SteamID2 (STEAM_0:0:11101)
+	SourceSteam2
+
+	// A SteamID3 ([U:1:22202])
+	SourceSteam3
+
+	// A profile or group URL holding the ID (steamcommunity.com/profiles/...)
+	SourceProfileURL
+
+	// An invite link (s.team/p/... or steamcommunity.com/user/...)
+	SourceInviteURL
+
+	// A vanity name looked up as a profile (steamcommunity.com/id/...)
+	SourceVanityProfile
+
+	// A vanity name looked up as a group (steamcommunity.com/groups/...)
+	SourceVanityGroup
+
+	// A vanity name looked up as an official game group (steamcommunity.com/games/...)
+	SourceVanityGameGroup
+
+	// A CS:GO friend code (SUCVS-FADA)
+	SourceFriendCode
+)
+
+var sourceNames = map[Source]string{
+	SourceSteamID64:       "SteamID64",
+	SourceSteam2:          "SteamID2",
+	SourceSteam3:          "SteamID3",
+	SourceProfileURL:      "profile URL",
+	SourceInviteURL:       "invite URL",
+	SourceVanityProfile:   "profile vanity URL",
+	SourceVanityGroup:     "group vanity URL",
+	SourceVanityGameGroup: "game group vanity URL",
+	SourceFriendCode:      "friend code",
+}
+
+// String describes the source.
+func (s Source) String() string {
+	if name, ok := sourceNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Source(%d)", int(s))
+}
+
+// Vanity reports whether the source required a ResolveVanityURL lookup.
+func (s Source) Vanity() bool {
+	switch s {
+	case SourceVanityProfile, SourceVanityGroup, SourceVanityGameGroup:
+		return true
+	}
+	return false
+}
+
+// URLType is the url_type sent to ISteamUser/ResolveVanityURL.
+type URLType int32
+
+// The vanity URL types.
+const (
+	URLTypeProfile   URLType = 1
+	URLTypeGroup     URLType = 2
+	URLTypeGameGroup URLType = 3
+)
+
+// source returns the Source of a lookup of the URL type.
+func (t URLType) source() Source {
+	switch t {
+	case URLTypeGroup:
+		return SourceVanityGroup
+	case URLTypeGameGroup:
+		return SourceVanityGameGroup
+	}
+	return SourceVanityProfile
+}
+
+var (
+	// Vanity names are letters, digits, underscores and hyphens.
+	vanityPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{2,32}$`)
+
+	// Friend codes are SUCVS-FADA (or AAAA-SUCVS-FADA).
+	friendCodePattern = regexp.MustCompile(`^(?i:(AAAA-)?[A-Z2-9]{5}-[A-Z2-9]{4})$`)
+)
+
+// parsed is an input understood without the WebAPI: either an ID, or a
+// vanity name (with the URL types it could be).
+type parsed struct {
+	id     steamid.SteamID
+	source Source
+
+	vanity   string
+	urlTypes []URLType
+}
+
+// parseInput works out what input is. Bare vanity names may be any of
+// the given URL types.
+func parseInput(input string, urlTypes []URLType) (parsed, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return parsed{}, fmt.Errorf("empty input")
+	}
+
+	upper := strings.ToUpper(s)
+	switch {
+	case strings.HasPrefix(upper, "STEAM_"):
+		id, err := steamid.ParseSteam2(s)
+		return parsed{id: id, source: SourceSteam2}, err
+	case strings.HasPrefix(s, "[") || (len(s) > 1 && s[1] == ':'):
+		id, err := steamid.ParseSteam3(s)
+		return parsed{id: id, source: SourceSteam3}, err
+	case isDigits(s):
+		id, err := steamid.ParseSteamID64(s)
+		return parsed{id: id, source: SourceSteamID64}, err
+	}
+
+	if host, path, ok := splitURL(s); ok {
+		return parseURL(input, host, path)
+	}
+
+	// Friend codes could also be vanity names, but the checksum makes a
+	// mistake unlikely
+	if friendCodePattern.MatchString(s) {
+		if id, err := steamid.ParseFriendCode(s); err == nil {
+			return parsed{id: id, source: SourceFriendCode}, nil
+		}
+	}
+
+	if vanityPattern.MatchString(s) {
+		return parsed{vanity: s, urlTypes: urlTypes}, nil
+	}
+	return parsed{}, fmt.Errorf("'%s' is not a SteamID, profile URL or vanity name", input)
+}
+
+// splitURL splits a URL (with or without a scheme) into its host and
+// path segments.
+func splitURL(s string) (host string, path []string, ok bool) {
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	}
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, "/")
+	host = strings.TrimPrefix(strings.ToLower(parts[0]), "www.")
+	if !strings.Contains(host, ".") || len(parts) < 2 {
+		return "", nil, false
+	}
+	for _, part := range parts[1:] {
+		if part != "" {
+			path = append(path, part)
+		}
+	}
+	return host, path, true
+}
+
+// parseURL parses a steamcommunity.com or s.team URL.
+func parseURL(input string, host string, path []string) (parsed, error) {
+	if len(path) < 2 {
+		return parsed{}, fmt.Errorf("'%s' is not a profile URL", input)
+	}
+
+	switch host {
+	case "steamcommunity.com":
+		switch path[0] {
+		case "profiles", "gid":
+			id, err := steamid.Parse(path[1])
+			return parsed{id: id, source: SourceProfileURL}, err
+		case "id":
+			return parsed{vanity: path[1], urlTypes: []URLType{URLTypeProfile}}, nil
+		case "groups":
+			return parsed{vanity: path[1], urlTypes: []URLType{URLTypeGroup}}, nil
+		case "games":
+			return parsed{vanity: path[1], urlTypes: []URLType{URLTypeGameGroup}}, nil
+		case "user":
+			id, err := steamid.ParseInviteCode(path[1])
+			return parsed{id: id, source: SourceInviteURL}, err
+		}
+	case "s.team":
+		if path[0] == "p" {
+			id, err := steamid.ParseInviteCode(path[1])
+			return parsed{id: id, source: SourceInviteURL}, err
+		}
+	}
+	return parsed{}, fmt.Errorf("'%s' is not a profile URL", input)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
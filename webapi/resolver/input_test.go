@@ -0,0 +1,64 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/awstanley/GoSteam/steamid"
+)
+
+func TestParseInput(t *testing.T) {
+	const gabe steamid.SteamID = 76561197960287930
+	all := []URLType{URLTypeProfile, URLTypeGroup, URLTypeGameGroup}
+
+	tests := []struct {
+		input string
+		want  parsed
+	}{
+		{"76561197960287930", parsed{id: gabe, source: SourceSteamID64}},
+		{"STEAM_0:0:11101", parsed{id: gabe, source: SourceSteam2}},
+		{"[U:1:22202]", parsed{id: gabe, source: SourceSteam3}},
+		{"https://steamcommunity.com/profiles/76561197960287930/", parsed{id: gabe, source: SourceProfileURL}},
+		{"steamcommunity.com/profiles/[U:1:22202]", parsed{id: gabe, source: SourceProfileURL}},
+		{"https://s.team/p/hj-qp", parsed{id: gabe, source: SourceInviteURL}},
+		{"https://s.team/p/hj-qp/ABCDEFGH", parsed{id: gabe, source: SourceInviteURL}},
+		{"s.team/p/HJQP?utm=1", parsed{id: gabe, source: SourceInviteURL}},
+		{"https://steamcommunity.com/user/hj-qp/ABCDEFGH", parsed{id: gabe, source: SourceInviteURL}},
+		{"SUCVS-FADA", parsed{id: gabe, source: SourceFriendCode}},
+		{"https://steamcommunity.com/id/gabelogannewell", parsed{vanity: "gabelogannewell", urlTypes: []URLType{URLTypeProfile}}},
+		{"https://steamcommunity.com/groups/valve", parsed{vanity: "valve", urlTypes: []URLType{URLTypeGroup}}},
+		{"https://steamcommunity.com/games/tf2", parsed{vanity: "tf2", urlTypes: []URLType{URLTypeGameGroup}}},
+		{"gabelogannewell", parsed{vanity: "gabelogannewell", urlTypes: all}},
+	}
+
+	for _, test := range tests {
+		got, err := parseInput(test.input, all)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestParseInputErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"   ",
+		"https://s.team/p/",
+		"https://s.team/p/aaaa-aaaa",
+		"https://s.team/q/hj-qp",
+		"https://example.com/profiles/76561197960287930",
+		"not a vanity name!",
+	} {
+		if got, err := parseInput(input, []URLType{URLTypeProfile}); err == nil {
+			t.Errorf("%q: got %+v, want an error", input, got)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+// Package resolver turns whatever users paste (SteamIDs in any form,
+// profile, group and invite URLs, friend codes or vanity names) into
+// SteamIDs.
+//
+// IDs, codes and URLs holding them are handled locally; vanity names are
+// looked up with ISteamUser/ResolveVanityURL, and the results (including
+// names matching nothing, for a shorter time) cached.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/awstanley/GoSteam/steam"
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/ISteamUser"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// Result is a resolved SteamID.
+type Result struct {
+	// The SteamID
+	ID steamid.SteamID
+
+	// Where it came from
+	Source Source
+
+	// True if a vanity lookup was answered from the cache
+	Cached bool
+}
+
+// Resolver resolves inputs to SteamIDs; it is safe for concurrent use.
+type Resolver struct {
+	conn *core.Connection
+
+	// Types tried, in order, for bare vanity names
+	urlTypes []URLType
+
+	// How long vanity lookups are cached (zero disables the cache)
+	ttl time.Duration
+
+	// How long lookups matching nothing are cached (zero disables it)
+	negativeTTL time.Duration
+
+	// The most lookups cached
+	cacheSize int
+
+	// The most lookups ResolveAll runs at once
+	concurrency int
+
+	// How long a vanity lookup may run, whoever is waiting for it
+	timeout time.Duration
+
+	// Lookup results: the SteamID64 in decimal, or nothing for no match
+	cache *core.MemoryCache
+
+	mu       sync.Mutex
+	inflight map[lookup]*pending
+}
+
+// lookup is a single ResolveVanityURL call.
+type lookup struct {
+	name    string
+	urlType URLType
+}
+
+// cacheKey returns the key the lookup is cached under.
+func (l lookup) cacheKey() string {
+	return fmt.Sprintf("%d:%s", int32(l.urlType), l.name)
+}
+
+// pending is a lookup in progress, shared by everyone waiting on it.
+type pending struct {
+	done chan struct{}
+	id   steamid.SteamID
+	err  error
+}
+
+// Option configures a Resolver.
+type Option func(r *Resolver)
+
+// WithURLTypes sets the URL types tried, in order, for bare vanity names
+// (by default profiles, then groups, then official game groups).
+func WithURLTypes(types ...URLType) Option {
+	return func(r *Resolver) {
+		r.urlTypes = append([]URLType(nil), types...)
+	}
+}
+
+// WithCacheTTL sets how long vanity lookups are cached (an hour by
+// default); zero disables the cache.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(r *Resolver) {
+		r.ttl = ttl
+	}
+}
+
+// WithNegativeCacheTTL sets how long lookups matching nothing are cached
+// (five minutes by default); zero disables caching them.
+func WithNegativeCacheTTL(ttl time.Duration) Option {
+	return func(r *Resolver) {
+		r.negativeTTL = ttl
+	}
+}
+
+// WithCacheSize sets the most vanity lookups cached (10000 by default);
+// the least recently used are dropped first.
+func WithCacheSize(n int) Option {
+	return func(r *Resolver) {
+		if n > 0 {
+			r.cacheSize = n
+		}
+	}
+}
+
+// WithConcurrency sets the most lookups ResolveAll runs at once (4 by default).
+func WithConcurrency(n int) Option {
+	return func(r *Resolver) {
+		if n > 0 {
+			r.concurrency = n
+		}
+	}
+}
+
+// WithTimeout sets how long a vanity lookup may run (30 seconds by
+// default). Lookups are shared by everyone resolving the same name, so
+// they run to completion (or this timeout) even if the caller which
+// started one gives up.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Resolver) {
+		if timeout > 0 {
+			r.timeout = timeout
+		}
+	}
+}
+
+// New creates a Resolver looking vanity names up over conn.
+func New(conn *core.Connection, options ...Option) *Resolver {
+	r := &Resolver{
+		conn:        conn,
+		urlTypes:    []URLType{URLTypeProfile, URLTypeGroup, URLTypeGameGroup},
+		ttl:         time.Hour,
+		negativeTTL: 5 * time.Minute,
+		cacheSize:   10000,
+		concurrency: 4,
+		timeout:     30 * time.Second,
+		inflight:    make(map[lookup]*pending),
+	}
+	for _, option := range options {
+		option(r)
+	}
+	r.cache = core.NewMemoryCache(r.cacheSize)
+	return r
+}
+
+// Resolve resolves a single input. Vanity names matching nothing return
+// an error matching steam.EResultNoMatch.
+func (r *Resolver) Resolve(ctx context.Context, input string) (Result, error) {
+	p, err := parseInput(input, r.urlTypes)
+	if err != nil {
+		return Result{}, err
+	}
+	if p.vanity == "" {
+		return Result{ID: p.id, Source: p.source}, nil
+	}
+
+	err = steam.EResultNoMatch
+	for _, urlType := range p.urlTypes {
+		var id steamid.SteamID
+		var hit bool
+		id, hit, err = r.lookup(ctx, lookup{name: p.vanity, urlType: urlType})
+		if err == nil {
+			return Result{ID: id, Source: urlType.source(), Cached: hit}, nil
+		}
+		if !errors.Is(err, steam.EResultNoMatch) {
+			break
+		}
+	}
+	return Result{}, err
+}
+
+// ResolveAll resolves many inputs, running up to the configured number of
+// lookups at once; repeated names are only looked up once. The results
+// and errors line up with inputs.
+func (r *Resolver) ResolveAll(ctx context.Context, inputs []string) ([]Result, []error) {
+	results := make([]Result, len(inputs))
+	errs := make([]error, len(inputs))
+
+	slots := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+	for i, input := range inputs {
+		wg.Add(1)
+		go func(i int, input string) {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-slots }()
+			results[i], errs[i] = r.Resolve(ctx, input)
+		}(i, input)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+// lookup resolves a vanity name, through the cache and any identical
+// lookup already in progress.
+func (r *Resolver) lookup(ctx context.Context, key lookup) (id steamid.SteamID, hit bool, err error) {
+	// Vanity names are not case sensitive
+	key.name = strings.ToLower(key.name)
+
+	r.mu.Lock()
+	if content, ok := r.cache.Get(key.cacheKey()); ok {
+		r.mu.Unlock()
+		if len(content) == 0 {
+			return 0, true, noMatch()
+		}
+		value, err := strconv.ParseUint(string(content), 10, 64)
+		return steamid.SteamID(value), true, err
+	}
+	call, ok := r.inflight[key]
+	if !ok {
+		call = &pending{done: make(chan struct{})}
+		r.inflight[key] = call
+		go r.run(ctx, key, call)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.id, false, call.err
+	case <-ctx.Done():
+		return 0, false, ctx.Err()
+	}
+}
+
+// run performs a shared lookup. It is detached from the cancellation of
+// ctx (the context of whoever started it), so one caller giving up does
+// not fail the others waiting on it; the Resolver's timeout bounds it
+// instead.
+func (r *Resolver) run(ctx context.Context, key lookup, call *pending) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+	defer cancel()
+
+	call.id, call.err = r.resolveVanity(ctx, key)
+
+	r.mu.Lock()
+	delete(r.inflight, key)
+	switch {
+	case call.err == nil && r.ttl > 0:
+		r.cache.Set(key.cacheKey(), []byte(strconv.FormatUint(uint64(call.id), 10)), r.ttl)
+	case errors.Is(call.err, steam.EResultNoMatch) && r.negativeTTL > 0:
+		r.cache.Set(key.cacheKey(), nil, r.negativeTTL)
+	}
+	r.mu.Unlock()
+	close(call.done)
+}
+
+// resolveVanity calls ISteamUser/ResolveVanityURL.
+func (r *Resolver) resolveVanity(ctx context.Context, key lookup) (steamid.SteamID, error) {
+	method := ISteamUser.NewResolveVanityURLV1(key.name).SetUrlType(int32(key.urlType))
+	request, err := method.Request(r.conn)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := core.CallAndDecode[ISteamUser.ResolveVanityURLV1Response](ctx, r.conn, request)
+	if err != nil {
+		return 0, err
+	}
+	return response.SteamID, nil
+}
+
+// noMatch returns the error ResolveVanityURL gives for names matching
+// nothing, for answers from the cache.
+func noMatch() error {
+	return &core.ResultError{Code: steam.EResultNoMatch, Message: "No match"}
+}
+
+// Forget removes everything from the cache.
+func (r *Resolver) Forget() {
+	r.mu.Lock()
+	r.cache = core.NewMemoryCache(r.cacheSize)
+	r.mu.Unlock()
+}
@@ -0,0 +1,298 @@
+// Copyright 2016 A.W. Stanley All rights reserved.
+// Use of this source code is governed by a BSD-style
+// licence that can be found in the LICENCE.md file.
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awstanley/GoSteam/steam"
+	"github.com/awstanley/GoSteam/steamid"
+	"github.com/awstanley/GoSteam/webapi/core"
+)
+
+// vanityServer answers ResolveVanityURL from names, and records every
+// lookup it is asked for.
+type vanityServer struct {
+	*httptest.Server
+
+	// SteamIDs by URL type and vanity name
+	names map[URLType]map[string]steamid.SteamID
+
+	// If not nil, requests wait for it to be closed
+	gate chan struct{}
+
+	mu      sync.Mutex
+	lookups []lookup
+}
+
+func newVanityServer(t *testing.T) *vanityServer {
+	s := &vanityServer{
+		names: map[URLType]map[string]steamid.SteamID{
+			URLTypeProfile:   {"gabelogannewell": steamid.NewIndividual(22202)},
+			URLTypeGroup:     {"valve": steamid.NewClan(4)},
+			URLTypeGameGroup: {"tf2": steamid.NewClan(1)},
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *vanityServer) serve(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	urlType := URLTypeProfile
+	if value := query.Get("url_type"); value != "" {
+		n, _ := strconv.Atoi(value)
+		urlType = URLType(n)
+	}
+	name := query.Get("vanityurl")
+
+	s.mu.Lock()
+	s.lookups = append(s.lookups, lookup{name: name, urlType: urlType})
+	s.mu.Unlock()
+
+	if s.gate != nil {
+		<-s.gate
+	}
+
+	if id, ok := s.names[urlType][name]; ok {
+		fmt.Fprintf(w, `{"response": {"steamid": "%d", "success": 1}}`, uint64(id))
+		return
+	}
+	fmt.Fprint(w, `{"response": {"success": 42, "message": "No match"}}`)
+}
+
+// calls returns the number of lookups made so far.
+func (s *vanityServer) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.lookups)
+}
+
+func (s *vanityServer) resolver(options ...Option) *Resolver {
+	return New(core.NewConnectionWithOptions("key", core.WithBaseURI(s.URL)), options...)
+}
+
+func TestResolveFallback(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    steamid.SteamID
+		source  Source
+		lookups []URLType
+	}{
+		{"gabelogannewell", steamid.NewIndividual(22202), SourceVanityProfile, []URLType{URLTypeProfile}},
+		{"valve", steamid.NewClan(4), SourceVanityGroup, []URLType{URLTypeProfile, URLTypeGroup}},
+		{"tf2", steamid.NewClan(1), SourceVanityGameGroup, []URLType{URLTypeProfile, URLTypeGroup, URLTypeGameGroup}},
+		{"nobody", 0, 0, []URLType{URLTypeProfile, URLTypeGroup, URLTypeGameGroup}},
+		{"https://steamcommunity.com/groups/valve", steamid.NewClan(4), SourceVanityGroup, []URLType{URLTypeGroup}},
+		{"https://steamcommunity.com/id/valve", 0, 0, []URLType{URLTypeProfile}},
+		{"https://steamcommunity.com/games/tf2", steamid.NewClan(1), SourceVanityGameGroup, []URLType{URLTypeGameGroup}},
+	}
+
+	for _, test := range tests {
+		server := newVanityServer(t)
+		result, err := server.resolver().Resolve(context.Background(), test.input)
+
+		var types []URLType
+		server.mu.Lock()
+		for _, l := range server.lookups {
+			types = append(types, l.urlType)
+		}
+		server.mu.Unlock()
+		if !reflect.DeepEqual(types, test.lookups) {
+			t.Errorf("%s: looked up %v, want %v", test.input, types, test.lookups)
+		}
+
+		if test.want == 0 {
+			if !errors.Is(err, steam.EResultNoMatch) {
+				t.Errorf("%s: got %v, want EResultNoMatch", test.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.input, err)
+			continue
+		}
+		if result.ID != test.want || result.Source != test.source || result.Cached {
+			t.Errorf("%s: got %+v, want %d from %s", test.input, result, uint64(test.want), test.source)
+		}
+	}
+}
+
+func TestResolveCache(t *testing.T) {
+	server := newVanityServer(t)
+	r := server.resolver()
+	ctx := context.Background()
+
+	first, err := r.Resolve(ctx, "valve")
+	if err != nil || first.Cached {
+		t.Fatalf("got %+v, %v, want an uncached result", first, err)
+	}
+	second, err := r.Resolve(ctx, "VALVE")
+	if err != nil || !second.Cached || second.ID != first.ID || second.Source != first.Source {
+		t.Fatalf("got %+v, %v, want %+v from the cache", second, err, first)
+	}
+	if calls := server.calls(); calls != 2 {
+		t.Errorf("made %d lookups, want 2", calls)
+	}
+
+	// Misses are cached too
+	for i := 0; i < 2; i++ {
+		if _, err := r.Resolve(ctx, "nobody"); !errors.Is(err, steam.EResultNoMatch) {
+			t.Fatalf("got %v, want EResultNoMatch", err)
+		}
+	}
+	if calls := server.calls(); calls != 5 {
+		t.Errorf("made %d lookups, want 5", calls)
+	}
+
+	r.Forget()
+	if result, err := r.Resolve(ctx, "valve"); err != nil || result.Cached {
+		t.Errorf("got %+v, %v after Forget, want an uncached result", result, err)
+	}
+}
+
+func TestResolveCacheExpiry(t *testing.T) {
+	server := newVanityServer(t)
+	r := server.resolver(WithCacheTTL(50*time.Millisecond), WithNegativeCacheTTL(20*time.Millisecond))
+	ctx := context.Background()
+
+	r.Resolve(ctx, "gabelogannewell")
+	r.Resolve(ctx, "nobody")
+	if calls := server.calls(); calls != 4 {
+		t.Fatalf("made %d lookups, want 4", calls)
+	}
+
+	// The miss expires first
+	time.Sleep(30 * time.Millisecond)
+	if result, err := r.Resolve(ctx, "gabelogannewell"); err != nil || !result.Cached {
+		t.Errorf("got %+v, %v, want a cached result", result, err)
+	}
+	r.Resolve(ctx, "nobody")
+	if calls := server.calls(); calls != 7 {
+		t.Errorf("made %d lookups, want 7", calls)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if result, err := r.Resolve(ctx, "gabelogannewell"); err != nil || result.Cached {
+		t.Errorf("got %+v, %v, want an uncached result", result, err)
+	}
+	if calls := server.calls(); calls != 8 {
+		t.Errorf("made %d lookups, want 8", calls)
+	}
+}
+
+func TestResolveCacheDisabled(t *testing.T) {
+	server := newVanityServer(t)
+	r := server.resolver(WithCacheTTL(0), WithNegativeCacheTTL(0))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		r.Resolve(ctx, "gabelogannewell")
+		r.Resolve(ctx, "nobody")
+	}
+	if calls := server.calls(); calls != 8 {
+		t.Errorf("made %d lookups, want 8", calls)
+	}
+}
+
+func TestResolveCacheSize(t *testing.T) {
+	server := newVanityServer(t)
+	r := server.resolver(WithCacheSize(2), WithURLTypes(URLTypeProfile))
+	ctx := context.Background()
+
+	for _, name := range []string{"alpha", "bravo", "delta", "bravo", "alpha"} {
+		r.Resolve(ctx, name)
+	}
+
+	// alpha was dropped to make room for delta; bravo was still cached
+	if calls := server.calls(); calls != 4 {
+		t.Errorf("made %d lookups, want 4", calls)
+	}
+}
+
+func TestResolveShared(t *testing.T) {
+	const callers = 10
+	server := newVanityServer(t)
+	server.gate = make(chan struct{})
+	r := server.resolver()
+
+	var wg sync.WaitGroup
+	results := make([]Result, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = r.Resolve(context.Background(), "gabelogannewell")
+		}(i)
+	}
+
+	// Give every caller time to join the lookup before it is answered
+	time.Sleep(50 * time.Millisecond)
+	close(server.gate)
+	wg.Wait()
+
+	if calls := server.calls(); calls != 1 {
+		t.Errorf("made %d lookups, want 1", calls)
+	}
+	for i := range results {
+		if errs[i] != nil || results[i].ID != steamid.NewIndividual(22202) {
+			t.Errorf("caller %d: got %+v, %v", i, results[i], errs[i])
+		}
+	}
+}
+
+func TestResolveCallerCancel(t *testing.T) {
+	server := newVanityServer(t)
+	server.gate = make(chan struct{})
+	r := server.resolver()
+
+	// The first caller starts the lookup, then gives up on it
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := r.Resolve(ctx, "gabelogannewell")
+		first <- err
+	}()
+
+	second := make(chan Result)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		result, err := r.Resolve(context.Background(), "gabelogannewell")
+		if err != nil {
+			t.Errorf("second caller: unexpected error: %v", err)
+		}
+		second <- result
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller: got %v, want context.Canceled", err)
+	}
+
+	close(server.gate)
+	if result := <-second; result.ID != steamid.NewIndividual(22202) {
+		t.Errorf("second caller: got %+v", result)
+	}
+	if calls := server.calls(); calls != 1 {
+		t.Errorf("made %d lookups, want 1", calls)
+	}
+
+	// The lookup finished for the second caller, so it was cached
+	if result, err := r.Resolve(context.Background(), "gabelogannewell"); err != nil || !result.Cached {
+		t.Errorf("got %+v, %v, want a cached result", result, err)
+	}
+}